@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"scope-backend/internal/config"
+	"scope-backend/internal/database"
+	"scope-backend/internal/migrations"
+	mongomigrations "scope-backend/internal/migrations/mongo"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+func connectForMigrate() (*gorm.DB, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := database.ConnectDB(cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
+
+func connectForMongoMigrate() (*mongo.Database, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := database.ConnectMongo(cfg.Mongo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mongodb: %w", err)
+	}
+	return db, nil
+}
+
+func newMigrateCmd() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage Postgres schema migrations",
+	}
+
+	migrateCmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all pending migrations",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				db, err := connectForMigrate()
+				if err != nil {
+					return err
+				}
+				return migrations.Up(db)
+			},
+		},
+		&cobra.Command{
+			Use:   "down",
+			Short: "Roll back the most recently applied migration",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				db, err := connectForMigrate()
+				if err != nil {
+					return err
+				}
+				return migrations.Down(db, 1)
+			},
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show applied and pending migrations",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				db, err := connectForMigrate()
+				if err != nil {
+					return err
+				}
+				return migrations.Status(db)
+			},
+		},
+		&cobra.Command{
+			Use:   "redo",
+			Short: "Roll back and reapply the most recently applied migration",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				db, err := connectForMigrate()
+				if err != nil {
+					return err
+				}
+				return migrations.Redo(db)
+			},
+		},
+		&cobra.Command{
+			Use:   "squash",
+			Short: "Mark every registered migration as applied, as a baseline for fresh deployments",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				db, err := connectForMigrate()
+				if err != nil {
+					return err
+				}
+				return migrations.Squash(db)
+			},
+		},
+		&cobra.Command{
+			Use:   "mongo-up",
+			Short: "Apply all pending MongoDB schema migrations",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				db, err := connectForMongoMigrate()
+				if err != nil {
+					return err
+				}
+				return mongomigrations.Up(context.Background(), db)
+			},
+		},
+		&cobra.Command{
+			Use:   "mongo-status",
+			Short: "Show applied and pending MongoDB schema migrations",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				db, err := connectForMongoMigrate()
+				if err != nil {
+					return err
+				}
+				return mongomigrations.Status(context.Background(), db)
+			},
+		},
+	)
+
+	return migrateCmd
+}
@@ -0,0 +1,412 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"scope-backend/internal/config"
+	"scope-backend/internal/database"
+	"scope-backend/internal/feed/binance"
+	"scope-backend/internal/migrations"
+	mongomigrations "scope-backend/internal/migrations/mongo"
+	"scope-backend/internal/repository"
+	"scope-backend/internal/server"
+	"scope-backend/internal/services"
+	"scope-backend/internal/strategy"
+	"scope-backend/internal/worker"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// buildWiring connects to Postgres/MongoDB/Redis and constructs every
+// service that depends on them. It is used both for the initial boot and,
+// via Server.SetRestartFunc, for the setup wizard's restart-in-place.
+func buildWiring(cfg *config.Config) (server.Wiring, error) {
+	var w server.Wiring
+
+	db, err := database.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Printf("Warning: Failed to connect to database: %v", err)
+	}
+	w.DB = db
+
+	mongoDB, err := database.ConnectMongo(cfg.Mongo)
+	if err != nil {
+		log.Printf("Warning: Failed to connect to MongoDB: %v", err)
+	}
+	w.MongoDB = mongoDB
+
+	w.RedisClient = database.ConnectRedis(cfg.Redis)
+
+	if db != nil {
+		userRepo := repository.NewUserRepository(db)
+		w.AuthService = services.NewAuthService(userRepo)
+	}
+
+	w.MarketService = services.NewMarketService(w.RedisClient, cfg.Market.Seed)
+	switch cfg.Market.Feed {
+	case "binance":
+		bf := binance.NewFeed()
+		if err := w.MarketService.StartFeed(bf, cfg.Market.Symbols); err != nil {
+			log.Printf("Warning: failed to start Binance feed, falling back to simulator: %v", err)
+			w.MarketService.StartMarketSimulator()
+		} else {
+			w.MarketFeed = bf
+		}
+	default:
+		w.MarketService.StartMarketSimulator()
+	}
+
+	if mongoDB != nil {
+		w.NewsService = services.NewNewsService(mongoDB)
+		w.ScreenerService = services.NewScreenerService(mongoDB, w.RedisClient)
+		w.InsiderService = services.NewInsiderService(mongoDB)
+		w.SectorService = services.NewSectorService(mongoDB)
+		w.FundamentalsService = services.NewFundamentalsService(mongoDB)
+	}
+
+	if cfg.Futures.Enabled {
+		// The futures venue shares the spot venue's simulated price series
+		// (same Redis client, so MarketService's keys resolve to the same
+		// data); only the funding rate is venue-specific.
+		w.FuturesMarketService = services.NewMarketService(w.RedisClient, cfg.Market.Seed)
+
+		fundingRateInterval, err := time.ParseDuration(cfg.Futures.FundingRateInterval)
+		if err != nil {
+			return w, fmt.Errorf("invalid futures.funding_rate_interval %q: %w", cfg.Futures.FundingRateInterval, err)
+		}
+		w.FundingRateService = services.NewFundingRateService(w.RedisClient)
+		w.FundingRateService.StartSimulator(cfg.Market.Symbols, fundingRateInterval)
+	}
+
+	return w, nil
+}
+
+// parseGapStrategyConfig converts the string-typed config.GapStrategyConfig
+// (kept plain so it decodes straight off viper/yaml) into the decimal/duration
+// typed services.GapStrategyConfig the strategy itself runs on.
+func parseGapStrategyConfig(cfg config.GapStrategyConfig) (services.GapStrategyConfig, error) {
+	minSpread, err := decimal.NewFromString(cfg.MinSpread)
+	if err != nil {
+		return services.GapStrategyConfig{}, fmt.Errorf("invalid gap_strategy.min_spread %q: %w", cfg.MinSpread, err)
+	}
+	quantity, err := decimal.NewFromString(cfg.Quantity)
+	if err != nil {
+		return services.GapStrategyConfig{}, fmt.Errorf("invalid gap_strategy.quantity %q: %w", cfg.Quantity, err)
+	}
+	tickSize, err := decimal.NewFromString(cfg.TickSize)
+	if err != nil {
+		return services.GapStrategyConfig{}, fmt.Errorf("invalid gap_strategy.tick_size %q: %w", cfg.TickSize, err)
+	}
+	lotSize, err := decimal.NewFromString(cfg.LotSize)
+	if err != nil {
+		return services.GapStrategyConfig{}, fmt.Errorf("invalid gap_strategy.lot_size %q: %w", cfg.LotSize, err)
+	}
+	updateInterval, err := time.ParseDuration(cfg.UpdateInterval)
+	if err != nil {
+		return services.GapStrategyConfig{}, fmt.Errorf("invalid gap_strategy.update_interval %q: %w", cfg.UpdateInterval, err)
+	}
+
+	return services.GapStrategyConfig{
+		Symbol:          cfg.Symbol,
+		SourceExchange:  cfg.SourceExchange,
+		TradingExchange: cfg.TradingExchange,
+		MinSpread:       minSpread,
+		Quantity:        quantity,
+		TickSize:        tickSize,
+		LotSize:         lotSize,
+		UpdateInterval:  updateInterval,
+		DryRun:          cfg.DryRun,
+	}, nil
+}
+
+// parseFeeBudgetConfig converts the string-typed config.FeeBudgetConfig into
+// the decimal-typed services.FeeBudgetConfig FeeBudget runs on.
+func parseFeeBudgetConfig(cfg config.FeeBudgetConfig) (services.FeeBudgetConfig, error) {
+	dailyFeeBudgets := make(map[string]decimal.Decimal, len(cfg.DailyFeeBudgets))
+	for symbol, raw := range cfg.DailyFeeBudgets {
+		budget, err := decimal.NewFromString(raw)
+		if err != nil {
+			return services.FeeBudgetConfig{}, fmt.Errorf("invalid fee_budget.daily_fee_budgets[%s] %q: %w", symbol, raw, err)
+		}
+		dailyFeeBudgets[symbol] = budget
+	}
+
+	dailyMaxVolume, err := decimal.NewFromString(cfg.DailyMaxVolume)
+	if err != nil {
+		return services.FeeBudgetConfig{}, fmt.Errorf("invalid fee_budget.daily_max_volume %q: %w", cfg.DailyMaxVolume, err)
+	}
+
+	return services.FeeBudgetConfig{
+		DailyFeeBudgets: dailyFeeBudgets,
+		DailyMaxVolume:  dailyMaxVolume,
+	}, nil
+}
+
+// parseDecimalOrZero parses raw as a decimal, treating an empty string as an
+// explicit zero rather than an error, since strategy.InstanceConfig fields
+// like Quantity/PriceDeviation use a zero value to mean "use the strategy's
+// built-in default".
+func parseDecimalOrZero(raw string) (decimal.Decimal, error) {
+	if raw == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(raw)
+}
+
+// parseStrategyInstances converts the string-typed config.StrategyInstanceConfig
+// entries into the decimal/duration typed strategy.InstanceConfig TradingService runs on.
+func parseStrategyInstances(cfgs []config.StrategyInstanceConfig) ([]strategy.InstanceConfig, error) {
+	instances := make([]strategy.InstanceConfig, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		quantity, err := parseDecimalOrZero(cfg.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].quantity %q: %w", cfg.ID, cfg.Symbol, cfg.Quantity, err)
+		}
+		priceDeviation, err := parseDecimalOrZero(cfg.PriceDeviation)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].price_deviation %q: %w", cfg.ID, cfg.Symbol, cfg.PriceDeviation, err)
+		}
+
+		var interval time.Duration
+		if cfg.Interval != "" {
+			interval, err = time.ParseDuration(cfg.Interval)
+			if err != nil {
+				return nil, fmt.Errorf("invalid strategies[%s/%s].interval %q: %w", cfg.ID, cfg.Symbol, cfg.Interval, err)
+			}
+		}
+
+		quoteInvestment, err := parseDecimalOrZero(cfg.QuoteInvestment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].quote_investment %q: %w", cfg.ID, cfg.Symbol, cfg.QuoteInvestment, err)
+		}
+		takeProfitRatio, err := parseDecimalOrZero(cfg.TakeProfitRatio)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].take_profit_ratio %q: %w", cfg.ID, cfg.Symbol, cfg.TakeProfitRatio, err)
+		}
+		minNotional, err := parseDecimalOrZero(cfg.MinNotional)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].min_notional %q: %w", cfg.ID, cfg.Symbol, cfg.MinNotional, err)
+		}
+		circuitBreakLossThreshold, err := parseDecimalOrZero(cfg.CircuitBreakLossThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].circuit_break_loss_threshold %q: %w", cfg.ID, cfg.Symbol, cfg.CircuitBreakLossThreshold, err)
+		}
+
+		var coolDownInterval time.Duration
+		if cfg.CoolDownInterval != "" {
+			coolDownInterval, err = time.ParseDuration(cfg.CoolDownInterval)
+			if err != nil {
+				return nil, fmt.Errorf("invalid strategies[%s/%s].cool_down_interval %q: %w", cfg.ID, cfg.Symbol, cfg.CoolDownInterval, err)
+			}
+		}
+
+		incrementalQuoteQuantity, err := parseDecimalOrZero(cfg.IncrementalQuoteQuantity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].incremental_quote_quantity %q: %w", cfg.ID, cfg.Symbol, cfg.IncrementalQuoteQuantity, err)
+		}
+		leverage, err := parseDecimalOrZero(cfg.Leverage)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].leverage %q: %w", cfg.ID, cfg.Symbol, cfg.Leverage, err)
+		}
+		shortFundingRateHigh, err := parseDecimalOrZero(cfg.ShortFundingRateHigh)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].short_funding_rate_high %q: %w", cfg.ID, cfg.Symbol, cfg.ShortFundingRateHigh, err)
+		}
+		shortFundingRateLow, err := parseDecimalOrZero(cfg.ShortFundingRateLow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].short_funding_rate_low %q: %w", cfg.ID, cfg.Symbol, cfg.ShortFundingRateLow, err)
+		}
+
+		bandWidth, err := parseDecimalOrZero(cfg.BandWidth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].band_width %q: %w", cfg.ID, cfg.Symbol, cfg.BandWidth, err)
+		}
+		neutralWidth, err := parseDecimalOrZero(cfg.NeutralWidth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].neutral_width %q: %w", cfg.ID, cfg.Symbol, cfg.NeutralWidth, err)
+		}
+		spread, err := parseDecimalOrZero(cfg.Spread)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategies[%s/%s].spread %q: %w", cfg.ID, cfg.Symbol, cfg.Spread, err)
+		}
+
+		instances = append(instances, strategy.InstanceConfig{
+			ID:                        cfg.ID,
+			Symbol:                    cfg.Symbol,
+			Quantity:                  quantity,
+			Interval:                  interval,
+			PriceDeviation:            priceDeviation,
+			QuoteInvestment:           quoteInvestment,
+			MaxOrderCount:             cfg.MaxOrderCount,
+			TakeProfitRatio:           takeProfitRatio,
+			MinNotional:               minNotional,
+			CoolDownInterval:          coolDownInterval,
+			CircuitBreakLossThreshold: circuitBreakLossThreshold,
+			RecoverWhenStart:          cfg.RecoverWhenStart,
+			IncrementalQuoteQuantity:  incrementalQuoteQuantity,
+			Leverage:                  leverage,
+			ShortFundingRateHigh:      shortFundingRateHigh,
+			ShortFundingRateLow:       shortFundingRateLow,
+			Window:                    cfg.Window,
+			BandWidth:                 bandWidth,
+			NeutralWidth:              neutralWidth,
+			Spread:                    spread,
+			GridNum:                   cfg.GridNum,
+		})
+	}
+	return instances, nil
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	setupTokenFlag, _ := cmd.Flags().GetBool("setup-token")
+	autoMigrate, _ := cmd.Flags().GetBool("auto-migrate")
+
+	// Load Configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	setupMode := !config.ConfigFileFound() || setupTokenFlag
+
+	if db, err := database.ConnectDB(cfg.Database); err == nil {
+		pending, err := migrations.Pending(db)
+		if err != nil {
+			return fmt.Errorf("failed to check pending migrations: %w", err)
+		}
+		if len(pending) > 0 {
+			if !autoMigrate {
+				return fmt.Errorf("%d pending migration(s); run `scope-backend migrate up` or start with --auto-migrate", len(pending))
+			}
+			log.Printf("Applying %d pending migration(s) (--auto-migrate)...", len(pending))
+			if err := migrations.Up(db); err != nil {
+				return fmt.Errorf("auto-migrate failed: %w", err)
+			}
+		}
+	}
+
+	wiring, err := buildWiring(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize services: %w", err)
+	}
+
+	var feeBudget *services.FeeBudget
+	if wiring.MongoDB != nil {
+		feeBudgetCfg, err := parseFeeBudgetConfig(cfg.FeeBudget)
+		if err != nil {
+			return fmt.Errorf("invalid fee_budget config: %w", err)
+		}
+		feeBudget = services.NewFeeBudget(wiring.MongoDB, feeBudgetCfg)
+	}
+
+	var strategyStateStore *services.StrategyStateStore
+	var orderStore *services.OrderStore
+	var positionStore *services.PositionStore
+	if wiring.MongoDB != nil {
+		if err := mongomigrations.Up(context.Background(), wiring.MongoDB); err != nil {
+			return fmt.Errorf("mongo auto-migrate failed: %w", err)
+		}
+
+		strategyStateStore = services.NewStrategyStateStore(wiring.MongoDB)
+		orderStore = services.NewOrderStore(wiring.MongoDB)
+		positionStore = services.NewPositionStore(wiring.MongoDB)
+	}
+
+	var futuresSession *services.Session
+	if wiring.FuturesMarketService != nil {
+		futuresSession = services.NewSession(strategy.LegFutures, wiring.FuturesMarketService)
+	}
+
+	strategyInstances, err := parseStrategyInstances(cfg.Strategies)
+	if err != nil {
+		return fmt.Errorf("invalid strategies config: %w", err)
+	}
+	tradingService, err := services.NewTradingService(wiring.ScreenerService, wiring.MarketService, feeBudget, orderStore, positionStore, strategyStateStore, futuresSession, wiring.FundingRateService, strategyInstances)
+	if err != nil {
+		return fmt.Errorf("failed to initialize trading strategies: %w", err)
+	}
+
+	// Initialize Worker Pool
+	// In a real production app, you might run the worker server in a separate process
+	// or use a flag to decide whether to run as server or worker or both.
+	// Here we run both for simplicity.
+
+	// 1. Start Worker Server (Consumer)
+	taskProcessor := worker.NewTaskProcessor(wiring.RedisClient, tradingService, wiring.MarketFeed, feeBudget)
+	go func() {
+		if err := taskProcessor.Start(); err != nil {
+			log.Fatalf("could not run worker server: %v", err)
+		}
+	}()
+
+	// 2. Initialize Task Distributor (Producer)
+	taskDistributor := worker.NewTaskDistributor(wiring.RedisClient)
+
+	// Schedule Automated Trading Task (Every 1 minute)
+	go func() {
+		// Wait a bit for server to start
+		time.Sleep(10 * time.Second)
+		log.Println("Starting Automated Trading Scheduler...")
+
+		// Initial run
+		if err := taskDistributor.DistributeTaskAutomatedTrading(); err != nil {
+			log.Printf("Failed to schedule initial automated trading task: %v", err)
+		}
+
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := taskDistributor.DistributeTaskAutomatedTrading(); err != nil {
+				log.Printf("Failed to schedule automated trading task: %v", err)
+			}
+		}
+	}()
+
+	// Schedule the cross-exchange gap strategy task, if enabled.
+	if cfg.GapStrategy.Enabled {
+		gapCfg, err := parseGapStrategyConfig(cfg.GapStrategy)
+		if err != nil {
+			return fmt.Errorf("invalid gap_strategy config: %w", err)
+		}
+
+		go func() {
+			time.Sleep(10 * time.Second)
+			log.Printf("Starting Gap Strategy Scheduler for %s (every %s)...", gapCfg.Symbol, gapCfg.UpdateInterval)
+
+			payload := &worker.GapStrategyPayload{Config: gapCfg}
+			if err := taskDistributor.DistributeTaskGapStrategy(payload); err != nil {
+				log.Printf("Failed to schedule initial gap strategy task: %v", err)
+			}
+
+			ticker := time.NewTicker(gapCfg.UpdateInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				if err := taskDistributor.DistributeTaskGapStrategy(payload); err != nil {
+					log.Printf("Failed to schedule gap strategy task: %v", err)
+				}
+			}
+		}()
+	}
+
+	// Initialize HTTP Server
+	var setupToken string
+	if setupMode {
+		setupToken, err = server.GenerateSetupToken()
+		if err != nil {
+			return fmt.Errorf("failed to generate setup token: %w", err)
+		}
+		log.Printf("Starting in setup mode (no config.yaml found, or --setup-token passed). Configure this instance at POST /api/setup/* using bearer token: %s", setupToken)
+	}
+
+	srv := server.NewServer(cfg, wiring.DB, wiring.MongoDB, wiring.RedisClient, taskDistributor, wiring.AuthService, wiring.MarketService, wiring.NewsService, wiring.ScreenerService, wiring.InsiderService, wiring.SectorService, wiring.FundamentalsService, setupMode, setupToken)
+	srv.SetRestartFunc(buildWiring)
+
+	if err := srv.Run(); err != nil {
+		return fmt.Errorf("failed to run server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// Package backtest replays historical klines (and, for screener-driven
+// strategies, historical screener snapshots) through the same Strategy
+// interface package strategy's live orchestration drives (see
+// services.TradingService), so a strategy runs identically whether it's
+// backtested or live -- only where its market data and fills come from
+// differs. Like package strategy, it has no dependency on
+// internal/services: Deps is built from the Backtester's own simulated
+// matching engine instead of a live MarketService/TradingService, so a
+// backtest never touches a real (or simulated-live) venue.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"scope-backend/internal/feed"
+	"scope-backend/internal/strategy"
+
+	"github.com/shopspring/decimal"
+)
+
+// TimeRange bounds a backtest run to [Start, End); bars outside it are
+// ignored by Run. Screener snapshots are not clipped: each bar sees the
+// latest snapshot at or before its own time, which may predate Start.
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether t falls within the range.
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// ScreenerSnapshot is one historical screener scan's results as of
+// Timestamp, replayed through ScreenerCandidates the way live
+// ScreenerService.GetScreenerResults would have answered at that point in
+// time.
+type ScreenerSnapshot struct {
+	Timestamp  time.Time
+	Strategy   string // the screener strategy scanned, e.g. "momentum"
+	Candidates []strategy.ScreenerCandidate
+}
+
+// instance is one strategy.Strategy the Backtester drives, bound to its own
+// symbol's loaded klines.
+type instance struct {
+	symbol string
+	st     strategy.Strategy
+}
+
+// Backtester replays historical klines through the strategy registry (see
+// package strategy) without touching any live service. Every
+// ExecuteOrder-family call a strategy makes is routed to an in-memory
+// matching engine that fills at the current bar's close, offset by
+// SlippageBps, instead of a real venue -- this is what unblocks tuning a
+// parameter like momentum's PriceDeviation threshold (hardcoded at 3.0%
+// before it became configurable) against history instead of live capital.
+type Backtester struct {
+	Range       TimeRange
+	SlippageBps decimal.Decimal // applied against the bar's close on every fill; zero fills exactly at close
+
+	klines            map[string][]feed.Kline // symbol -> bars, sorted ascending by Timestamp
+	screenerSnapshots []ScreenerSnapshot       // sorted ascending by Timestamp
+
+	engine    *matchingEngine
+	instances []instance
+	clock     time.Time // current replay time; bounds GetPrice/GetSMA/ScreenerCandidates to data known "so far"
+}
+
+// NewBacktester builds a Backtester over r. A zero SlippageBps fills every
+// order exactly at the current bar's close.
+func NewBacktester(r TimeRange, slippageBps decimal.Decimal) *Backtester {
+	return &Backtester{
+		Range:       r,
+		SlippageBps: slippageBps,
+		klines:      make(map[string][]feed.Kline),
+		engine:      newMatchingEngine(slippageBps),
+	}
+}
+
+// LoadKlines registers symbol's historical bars, sorting them ascending by
+// Timestamp. Bars outside b.Range are kept (Run clips them), so callers can
+// load a single history file once and reuse it across multiple ranges.
+func (b *Backtester) LoadKlines(symbol string, bars []feed.Kline) {
+	sorted := make([]feed.Kline, len(bars))
+	copy(sorted, bars)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp < sorted[j].Timestamp })
+	b.klines[symbol] = sorted
+}
+
+// LoadScreenerSnapshots registers historical screener scans, sorting them
+// ascending by Timestamp.
+func (b *Backtester) LoadScreenerSnapshots(snapshots []ScreenerSnapshot) {
+	sorted := make([]ScreenerSnapshot, len(snapshots))
+	copy(sorted, snapshots)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+	b.screenerSnapshots = sorted
+}
+
+// AddStrategy instantiates and initializes the registered strategy cfg.ID
+// names (see package strategy), bound to this Backtester's simulated Deps,
+// and schedules it to replay against cfg.Symbol's loaded klines. It mirrors
+// services.NewTradingService's per-instance setup so a strategy needs no
+// backtest-specific code path.
+func (b *Backtester) AddStrategy(cfg strategy.InstanceConfig) error {
+	st, ok := strategy.New(cfg.ID)
+	if !ok {
+		return fmt.Errorf("unknown strategy id %q for symbol %s (known: %v)", cfg.ID, cfg.Symbol, strategy.IDs())
+	}
+
+	deps := strategy.Deps{
+		ExecuteOrder:           b.executeOrder,
+		ExecuteFractionalOrder: b.executeFractionalOrder,
+		GetPrice:               b.getPrice,
+		ScreenerCandidates:     b.screenerCandidates,
+		// State persistence is meaningless within a single backtest run (there's
+		// no restart to recover from), so SaveState/LoadState are no-ops,
+		// matching how TradingService degrades when stateStore is nil.
+		SaveState:            func(ctx context.Context, state any) error { return nil },
+		LoadState:            func(ctx context.Context, out any) (bool, error) { return false, nil },
+		GetFundingRate:       b.getFundingRate,
+		OpenFuturesPosition:  b.openFuturesPosition,
+		CloseFuturesPosition: b.closeFuturesPosition,
+		TransferCollateral:   func(ctx context.Context, symbol string, from, to strategy.Leg, amount decimal.Decimal) error { return nil },
+		GetSMA:               b.getSMA,
+		GetStdDev:            b.getStdDev,
+		GetBestBidAsk:        b.getBestBidAsk,
+		PlaceMakerOrder:      b.placeMakerOrder,
+		// The matching engine has no resting-order book, so PlaceMakerOrder
+		// above fills immediately instead of waiting to be canceled by a later
+		// tick; there is nothing left for Cancel(All)MakerOrders to do.
+		CancelAllMakerOrders: func(ctx context.Context) ([]string, error) { return nil, nil },
+		CancelOrder:          func(ctx context.Context, orderID string) error { return nil },
+		Params:               cfg,
+	}
+
+	if err := st.Init(context.Background(), deps); err != nil {
+		return fmt.Errorf("failed to init strategy %s/%s: %w", cfg.ID, cfg.Symbol, err)
+	}
+	b.instances = append(b.instances, instance{symbol: cfg.Symbol, st: st})
+	return nil
+}
+
+// Run replays every loaded symbol's klines in chronological order within
+// b.Range, driving each matching strategy instance's OnKline hook on every
+// bar and its Run tick once per bar (mirroring RunAutomatedStrategy's
+// per-tick cadence, at bar granularity instead of a wall-clock timer), then
+// returns a ProfitStats summary of every fill the matching engine recorded.
+func (b *Backtester) Run(ctx context.Context) (*ProfitStats, error) {
+	for _, bar := range b.mergedBars() {
+		b.clock = time.UnixMilli(bar.Timestamp)
+		b.engine.markPrice(bar.Symbol, bar.Close)
+
+		for _, inst := range b.instances {
+			if inst.symbol != bar.Symbol {
+				continue
+			}
+			inst.st.OnKline(bar)
+			if err := inst.st.Run(ctx); err != nil {
+				return nil, fmt.Errorf("backtest: strategy %s/%s: %w", inst.st.ID(), inst.symbol, err)
+			}
+		}
+	}
+	return b.engine.stats.Finalize(), nil
+}
+
+// mergedBars flattens every loaded symbol's klines into one chronological,
+// b.Range-clipped stream for Run to replay.
+func (b *Backtester) mergedBars() []feed.Kline {
+	var merged []feed.Kline
+	for _, bars := range b.klines {
+		for _, bar := range bars {
+			if !b.Range.Contains(time.UnixMilli(bar.Timestamp)) {
+				continue
+			}
+			merged = append(merged, bar)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Timestamp < merged[j].Timestamp })
+	return merged
+}
+
+func (b *Backtester) executeOrder(ctx context.Context, symbol, side string, quantity int, price decimal.Decimal) error {
+	return b.engine.fillAtClose(symbol, strategy.LegSpot, side, decimal.NewFromInt(int64(quantity)), b.clock)
+}
+
+func (b *Backtester) executeFractionalOrder(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) error {
+	return b.engine.fillAtClose(symbol, strategy.LegSpot, side, quantity, b.clock)
+}
+
+func (b *Backtester) openFuturesPosition(ctx context.Context, symbol, side string, quantity, price, leverage decimal.Decimal) error {
+	return b.engine.fillAtClose(symbol, strategy.LegFutures, side, quantity, b.clock)
+}
+
+func (b *Backtester) closeFuturesPosition(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) error {
+	return b.engine.fillAtClose(symbol, strategy.LegFutures, side, quantity, b.clock)
+}
+
+func (b *Backtester) getPrice(symbol string) (decimal.Decimal, error) {
+	return b.engine.priceAt(symbol)
+}
+
+// screenerCandidates returns the most recent loaded snapshot for
+// screenerStrategy as of b.clock, the way live
+// ScreenerService.GetScreenerResults answers with its latest ingested scan,
+// truncated to limit.
+func (b *Backtester) screenerCandidates(ctx context.Context, screenerStrategy string, limit int64) ([]strategy.ScreenerCandidate, error) {
+	var latest *ScreenerSnapshot
+	for i := range b.screenerSnapshots {
+		snapshot := &b.screenerSnapshots[i]
+		if snapshot.Strategy != screenerStrategy || snapshot.Timestamp.After(b.clock) {
+			continue
+		}
+		latest = snapshot
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	candidates := latest.Candidates
+	if limit > 0 && int64(len(candidates)) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// getBestBidAsk approximates the order book as zero-spread at the current
+// bar's close: the Backtester replays klines, not historical depth.
+func (b *Backtester) getBestBidAsk(symbol string) (bid, ask *decimal.Decimal, err error) {
+	price, err := b.engine.priceAt(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &price, &price, nil
+}
+
+// getFundingRate always reports a flat zero rate: the Backtester doesn't
+// load historical funding-rate history, so an xfunding backtest will show
+// no funding income or cost, only the hedge's spot/futures price spread.
+func (b *Backtester) getFundingRate(symbol string) (decimal.Decimal, error) {
+	return decimal.Zero, nil
+}
+
+func (b *Backtester) placeMakerOrder(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) (string, error) {
+	if err := b.engine.fillAtPrice(symbol, strategy.LegSpot, side, quantity, price, b.clock); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("backtest-%d", len(b.engine.stats.trades)), nil
+}
+
+// closesUpTo returns symbol's bar closes at or before b.clock, oldest first.
+func (b *Backtester) closesUpTo(symbol string) []decimal.Decimal {
+	bars := b.klines[symbol]
+	closes := make([]decimal.Decimal, 0, len(bars))
+	for _, bar := range bars {
+		if time.UnixMilli(bar.Timestamp).After(b.clock) {
+			break
+		}
+		closes = append(closes, bar.Close)
+	}
+	return closes
+}
+
+// getSMA computes the simple moving average of symbol's last window closes
+// known as of the current bar, mirroring (*services.IndicatorService).SMA.
+func (b *Backtester) getSMA(symbol string, window int) (decimal.Decimal, error) {
+	closes := b.closesUpTo(symbol)
+	if len(closes) < window {
+		return decimal.Decimal{}, fmt.Errorf("backtest: not enough history for %s SMA(%d): have %d", symbol, window, len(closes))
+	}
+	recent := closes[len(closes)-window:]
+	sum := decimal.Zero
+	for _, c := range recent {
+		sum = sum.Add(c)
+	}
+	return sum.Div(decimal.NewFromInt(int64(window))), nil
+}
+
+// getStdDev computes the population standard deviation of symbol's last
+// window closes known as of the current bar, mirroring
+// (*services.IndicatorService).StdDev.
+func (b *Backtester) getStdDev(symbol string, window int) (decimal.Decimal, error) {
+	closes := b.closesUpTo(symbol)
+	if len(closes) < window {
+		return decimal.Decimal{}, fmt.Errorf("backtest: not enough history for %s StdDev(%d): have %d", symbol, window, len(closes))
+	}
+	recent := closes[len(closes)-window:]
+
+	mean := decimal.Zero
+	for _, c := range recent {
+		mean = mean.Add(c)
+	}
+	mean = mean.Div(decimal.NewFromInt(int64(window)))
+
+	meanF, _ := mean.Float64()
+	var sumSquares float64
+	for _, c := range recent {
+		cf, _ := c.Float64()
+		diff := cf - meanF
+		sumSquares += diff * diff
+	}
+	variance := sumSquares / float64(window)
+	return decimal.NewFromFloat(math.Sqrt(variance)), nil
+}
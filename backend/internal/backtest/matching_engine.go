@@ -0,0 +1,110 @@
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	"scope-backend/internal/position"
+	"scope-backend/internal/strategy"
+
+	"github.com/shopspring/decimal"
+)
+
+// matchingEngine simulates order fills against the current bar's close,
+// offset by slippageBps, tracking the resulting per-(symbol,leg) positions
+// and recording every fill as a Trade for ProfitStats.
+type matchingEngine struct {
+	slippageBps decimal.Decimal
+	marks       map[string]decimal.Decimal // symbol -> latest bar close
+	positions   map[string]*position.State // "symbol" or "symbol/leg" -> position
+	stats       *statsAccumulator
+}
+
+func newMatchingEngine(slippageBps decimal.Decimal) *matchingEngine {
+	return &matchingEngine{
+		slippageBps: slippageBps,
+		marks:       make(map[string]decimal.Decimal),
+		positions:   make(map[string]*position.State),
+		stats:       newStatsAccumulator(),
+	}
+}
+
+// markPrice records close as symbol's latest bar close, for priceAt and
+// fillAtClose to read.
+func (e *matchingEngine) markPrice(symbol string, close decimal.Decimal) {
+	e.marks[symbol] = close
+}
+
+// priceAt returns symbol's latest marked bar close, erroring if no bar has
+// been replayed for it yet.
+func (e *matchingEngine) priceAt(symbol string) (decimal.Decimal, error) {
+	price, ok := e.marks[symbol]
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("backtest: no bar loaded yet for %s", symbol)
+	}
+	return price, nil
+}
+
+// positionKey is the key a (symbol, leg) pair's running position is tracked
+// under; leg is omitted for single-venue strategies, which never set it.
+func positionKey(symbol string, leg strategy.Leg) string {
+	if leg == "" {
+		return symbol
+	}
+	return symbol + "/" + string(leg)
+}
+
+// applySlippage offsets close against the trader by slippageBps: a BUY
+// fills above close, a SELL fills below it, modeling the market impact a
+// real taker order would face.
+func applySlippage(close decimal.Decimal, side string, slippageBps decimal.Decimal) decimal.Decimal {
+	adj := close.Mul(slippageBps).Div(decimal.NewFromInt(10000))
+	if side == "SELL" {
+		return close.Sub(adj)
+	}
+	return close.Add(adj)
+}
+
+// fillAtClose fills quantity of symbol/leg at the current bar's close,
+// offset by slippageBps -- the matching engine's equivalent of
+// (*services.TradingService).ExecuteOrder, but against historical data
+// instead of a live simulated fill.
+func (e *matchingEngine) fillAtClose(symbol string, leg strategy.Leg, side string, quantity decimal.Decimal, timestamp time.Time) error {
+	close, ok := e.marks[symbol]
+	if !ok {
+		return fmt.Errorf("backtest: no bar loaded yet for %s", symbol)
+	}
+	return e.fill(symbol, leg, side, quantity, applySlippage(close, side, e.slippageBps), timestamp)
+}
+
+// fillAtPrice fills quantity of symbol/leg at exactly price, with no
+// slippage applied -- used for maker fills, which quote their own price
+// rather than taking the market's.
+func (e *matchingEngine) fillAtPrice(symbol string, leg strategy.Leg, side string, quantity, price decimal.Decimal, timestamp time.Time) error {
+	return e.fill(symbol, leg, side, quantity, price, timestamp)
+}
+
+// fill folds one order into symbol/leg's running position via
+// position.ApplyFill -- the same weighted-average-cost accounting, flip
+// included, that services.PositionStore.ApplyFill uses live -- and records
+// it as a Trade.
+func (e *matchingEngine) fill(symbol string, leg strategy.Leg, side string, quantity, price decimal.Decimal, timestamp time.Time) error {
+	key := positionKey(symbol, leg)
+	pos, ok := e.positions[key]
+	if !ok {
+		pos = &position.State{}
+		e.positions[key] = pos
+	}
+
+	trade := Trade{Symbol: symbol, Leg: leg, Side: side, Quantity: quantity, Price: price, Timestamp: timestamp}
+
+	newState, realized, err := position.ApplyFill(*pos, side, quantity, price)
+	if err != nil {
+		return fmt.Errorf("backtest: %w", err)
+	}
+	*pos = newState
+	trade.RealizedPnL = realized
+
+	e.stats.record(trade)
+	return nil
+}
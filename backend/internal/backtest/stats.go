@@ -0,0 +1,114 @@
+package backtest
+
+import (
+	"math"
+	"time"
+
+	"scope-backend/internal/strategy"
+
+	"github.com/shopspring/decimal"
+)
+
+// Trade is one fill the matching engine recorded.
+type Trade struct {
+	Symbol      string
+	Leg         strategy.Leg
+	Side        string
+	Quantity    decimal.Decimal
+	Price       decimal.Decimal
+	Timestamp   time.Time
+	RealizedPnL decimal.Decimal // zero on an entry; set on a fill that reduces a position
+}
+
+// ProfitStats summarizes every trade a Backtester run recorded.
+type ProfitStats struct {
+	TotalTrades int
+	// WinRate is the fraction of closing trades (RealizedPnL != 0) that were
+	// profitable; zero if the run recorded no closing trades.
+	WinRate decimal.Decimal
+	// MaxDrawdown is the largest peak-to-trough drop in cumulative realized
+	// PnL across closing trades, in the same units as PnL (not a percentage).
+	MaxDrawdown decimal.Decimal
+	// Sharpe is the mean realized PnL per closing trade divided by its
+	// sample (n-1) standard deviation -- a per-trade ratio, not annualized,
+	// since the Backtester has no fixed-length period to annualize against.
+	// It is zero if fewer than two closing trades were recorded.
+	Sharpe      decimal.Decimal
+	PnLBySymbol map[string]decimal.Decimal
+	Trades      []Trade
+}
+
+// statsAccumulator collects trades as the matching engine fills them and
+// computes ProfitStats once the run completes.
+type statsAccumulator struct {
+	trades      []Trade
+	pnlBySymbol map[string]decimal.Decimal
+}
+
+func newStatsAccumulator() *statsAccumulator {
+	return &statsAccumulator{pnlBySymbol: make(map[string]decimal.Decimal)}
+}
+
+func (a *statsAccumulator) record(t Trade) {
+	a.trades = append(a.trades, t)
+	a.pnlBySymbol[t.Symbol] = a.pnlBySymbol[t.Symbol].Add(t.RealizedPnL)
+}
+
+// Finalize computes ProfitStats from every trade recorded so far.
+func (a *statsAccumulator) Finalize() *ProfitStats {
+	stats := &ProfitStats{
+		TotalTrades: len(a.trades),
+		PnLBySymbol: a.pnlBySymbol,
+		Trades:      a.trades,
+	}
+
+	var closing []decimal.Decimal
+	for _, t := range a.trades {
+		if !t.RealizedPnL.IsZero() {
+			closing = append(closing, t.RealizedPnL)
+		}
+	}
+	if len(closing) == 0 {
+		return stats
+	}
+
+	wins := 0
+	cumulative := decimal.Zero
+	peak := decimal.Zero
+	maxDrawdown := decimal.Zero
+	sum := 0.0
+	for _, pnl := range closing {
+		if pnl.IsPositive() {
+			wins++
+		}
+
+		cumulative = cumulative.Add(pnl)
+		if cumulative.GreaterThan(peak) {
+			peak = cumulative
+		}
+		if drawdown := peak.Sub(cumulative); drawdown.GreaterThan(maxDrawdown) {
+			maxDrawdown = drawdown
+		}
+
+		f, _ := pnl.Float64()
+		sum += f
+	}
+	stats.WinRate = decimal.NewFromInt(int64(wins)).Div(decimal.NewFromInt(int64(len(closing))))
+	stats.MaxDrawdown = maxDrawdown
+
+	mean := sum / float64(len(closing))
+	if len(closing) > 1 {
+		var sumSquares float64
+		for _, pnl := range closing {
+			f, _ := pnl.Float64()
+			diff := f - mean
+			sumSquares += diff * diff
+		}
+		stddev := math.Sqrt(sumSquares / float64(len(closing)-1))
+		if stddev > 0 {
+			stats.Sharpe = decimal.NewFromFloat(mean / stddev)
+		}
+	}
+
+	return stats
+}
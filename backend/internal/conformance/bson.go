@@ -0,0 +1,47 @@
+package conformance
+
+import (
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// toBSON converts a vector's mongo_docs entry (decoded from JSON into plain
+// Go maps/slices) into a bson.D suitable for a mocked Find response.
+//
+// Raw JSON can't represent NaN or +/-Inf, so vectors spell those out as the
+// strings "NaN", "Infinity", and "-Infinity"; toBSON turns them back into
+// the actual float64 special values a Mongo driver would hand back.
+func toBSON(doc map[string]any) bson.D {
+	d := make(bson.D, 0, len(doc))
+	for k, v := range doc {
+		d = append(d, bson.E{Key: k, Value: toBSONValue(v)})
+	}
+	return d
+}
+
+func toBSONValue(v any) any {
+	switch t := v.(type) {
+	case string:
+		switch t {
+		case "NaN":
+			return math.NaN()
+		case "Infinity":
+			return math.Inf(1)
+		case "-Infinity":
+			return math.Inf(-1)
+		default:
+			return t
+		}
+	case map[string]any:
+		return toBSON(t)
+	case []any:
+		arr := make(bson.A, len(t))
+		for i, e := range t {
+			arr[i] = toBSONValue(e)
+		}
+		return arr
+	default:
+		return t
+	}
+}
@@ -0,0 +1,119 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"scope-backend/internal/services"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// update regenerates every vector's expected_json from the service's actual
+// output instead of asserting against it: `go test ./internal/conformance/... -update`.
+var update = flag.Bool("update", false, "regenerate expected_json for each vector from the actual service output")
+
+const vectorsDir = "testdata/vectors"
+
+// collectionFor returns the Mongo collection each conformance service reads
+// from, mirroring the NewXxxService constructors in internal/services.
+func collectionFor(service string) (string, error) {
+	switch service {
+	case "sector":
+		return "sector_performance", nil
+	case "insider":
+		return "insider_trades", nil
+	default:
+		return "", fmt.Errorf("unknown conformance service %q", service)
+	}
+}
+
+// runService builds the named service against mt's mocked database and
+// returns the JSON encoding of its sanitized result.
+func runService(mt *mtest.T, service string, limit int64) ([]byte, error) {
+	switch service {
+	case "sector":
+		svc := services.NewSectorService(mt.DB)
+		rows, err := svc.GetSectorPerformance(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(rows)
+	case "insider":
+		svc := services.NewInsiderService(mt.DB)
+		rows, err := svc.GetInsiderTrades(context.Background(), limit)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(rows)
+	default:
+		return nil, fmt.Errorf("unknown conformance service %q", service)
+	}
+}
+
+func jsonEqual(actual, expected []byte) bool {
+	if len(expected) == 0 {
+		return false
+	}
+	var a, b any
+	if err := json.Unmarshal(actual, &a); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(expected, &b); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// TestConformanceVectors replays every vector under testdata/vectors against
+// its named service, backed by a mocked Mongo wire protocol (mtest), and
+// asserts the service's JSON output deep-equals expected_json. Run with
+// -update to regenerate expected_json after a deliberate service change.
+func TestConformanceVectors(t *testing.T) {
+	vectors, err := LoadVectors(vectorsDir)
+	if err != nil {
+		t.Fatalf("failed to load vectors: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("no conformance vectors found under " + vectorsDir)
+	}
+
+	for name, v := range vectors {
+		name, v := name, v
+		collection, err := collectionFor(v.Service)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock).CollectionName(collection))
+		mt.RunOpts(name, mtest.NewOptions().CollectionName(collection), func(mt *mtest.T) {
+			docs := make([]bson.D, 0, len(v.MongoDocs))
+			for _, d := range v.MongoDocs {
+				docs = append(docs, toBSON(d))
+			}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, mt.DB.Name()+"."+collection, mtest.FirstBatch, docs...))
+
+			actual, err := runService(mt, v.Service, v.Limit)
+			if err != nil {
+				mt.Fatalf("service call failed: %v", err)
+			}
+
+			if *update {
+				v.ExpectedJSON = actual
+				if err := SaveVector(vectorsDir, name, v); err != nil {
+					mt.Fatalf("failed to update vector: %v", err)
+				}
+				return
+			}
+
+			if !jsonEqual(actual, v.ExpectedJSON) {
+				mt.Fatalf("vector %s: got %s, want %s", name, actual, v.ExpectedJSON)
+			}
+		})
+	}
+}
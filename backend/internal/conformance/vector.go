@@ -0,0 +1,58 @@
+// Package conformance runs Mongo-backed service methods against recorded
+// test vectors so sanitization/field-mapping behavior can't silently drift
+// between services like SectorService and InsiderService.
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Vector describes one conformance case: the mongo documents a service
+// should see, the arguments to call it with, and the JSON it must produce.
+type Vector struct {
+	Service      string           `json:"service"`
+	MongoDocs    []map[string]any `json:"mongo_docs"`
+	Limit        int64            `json:"limit"`
+	ExpectedJSON json.RawMessage  `json:"expected_json"`
+}
+
+// LoadVectors reads every *.json file in dir into a Vector, keyed by file name.
+func LoadVectors(dir string) (map[string]Vector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vectors dir: %w", err)
+	}
+
+	vectors := make(map[string]Vector, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+
+		var v Vector
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		vectors[entry.Name()] = v
+	}
+	return vectors, nil
+}
+
+// SaveVector writes v back to dir/name, used by `go test -update` to
+// regenerate expected_json from a service's actual output.
+func SaveVector(dir, name string, v Vector) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal vector: %w", err)
+	}
+	data = append(data, '\n')
+	return os.WriteFile(filepath.Join(dir, name), data, 0o644)
+}
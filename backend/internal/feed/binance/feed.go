@@ -0,0 +1,364 @@
+// Package binance implements feed.MarketFeed against Binance's public
+// combined WebSocket stream.
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"scope-backend/internal/feed"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultBaseURL  = "wss://stream.binance.com:9443"
+	defaultRESTURL  = "https://api.binance.com"
+	pingInterval    = 3 * time.Minute
+	pongWaitTimeout = 10 * time.Minute
+	reconnectDelay  = 2 * time.Second
+	maxReconnectDelay = time.Minute
+)
+
+// Feed connects to Binance's combined WebSocket stream
+// (wss://stream.binance.com:9443/stream?streams=...) and fans trade,
+// book-ticker, and kline messages out to the handlers registered via
+// SubscribeTrades/SubscribeBookTicker/SubscribeKlines. The connection is
+// rebuilt automatically whenever the subscribed stream set changes or the
+// socket drops.
+type Feed struct {
+	baseURL    string
+	restClient *http.Client
+	limiter    *rate.Limiter // throttles REST calls used to prime state (5 rps)
+
+	mu         sync.Mutex
+	streams    []string
+	generation int
+	conn       *websocket.Conn
+	closed     bool
+
+	tradeHandler      func(feed.Trade)
+	bookTickerHandler func(feed.BookTicker)
+	klineHandler      func(feed.Kline)
+}
+
+// NewFeed constructs a Binance feed. It does not dial until the first
+// Subscribe* call registers at least one stream.
+func NewFeed() *Feed {
+	return &Feed{
+		baseURL:    defaultBaseURL,
+		restClient: &http.Client{Timeout: 10 * time.Second},
+		limiter:    rate.NewLimiter(rate.Limit(5), 5),
+	}
+}
+
+type combinedEnvelope struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type tradeMessage struct {
+	Symbol    string `json:"s"`
+	Price     string `json:"p"`
+	Quantity  string `json:"q"`
+	TradeTime int64  `json:"T"`
+}
+
+type bookTickerMessage struct {
+	Symbol   string `json:"s"`
+	BidPrice string `json:"b"`
+	BidQty   string `json:"B"`
+	AskPrice string `json:"a"`
+	AskQty   string `json:"A"`
+}
+
+type klineMessage struct {
+	Symbol string `json:"s"`
+	Kline  struct {
+		StartTime int64  `json:"t"`
+		Open      string `json:"o"`
+		High      string `json:"h"`
+		Low       string `json:"l"`
+		Close     string `json:"c"`
+		Interval  string `json:"i"`
+		IsFinal   bool   `json:"x"`
+	} `json:"k"`
+}
+
+func (f *Feed) SubscribeTrades(symbols []string, handler func(feed.Trade)) error {
+	f.mu.Lock()
+	f.tradeHandler = handler
+	for _, s := range symbols {
+		f.streams = append(f.streams, streamName(s, "@trade"))
+	}
+	f.mu.Unlock()
+	return f.reconnect()
+}
+
+func (f *Feed) SubscribeBookTicker(symbols []string, handler func(feed.BookTicker)) error {
+	f.mu.Lock()
+	f.bookTickerHandler = handler
+	for _, s := range symbols {
+		f.streams = append(f.streams, streamName(s, "@bookTicker"))
+	}
+	f.mu.Unlock()
+	return f.reconnect()
+}
+
+func (f *Feed) SubscribeKlines(symbols []string, interval string, handler func(feed.Kline)) error {
+	f.mu.Lock()
+	f.klineHandler = handler
+	for _, s := range symbols {
+		f.streams = append(f.streams, streamName(s, "@kline_"+interval))
+	}
+	f.mu.Unlock()
+	return f.reconnect()
+}
+
+// Close tears down the WebSocket connection and stops all reconnect attempts.
+func (f *Feed) Close() error {
+	f.mu.Lock()
+	f.closed = true
+	f.generation++
+	conn := f.conn
+	f.conn = nil
+	f.mu.Unlock()
+
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+// RateLimiter exposes the token bucket guarding REST calls made to prime
+// state (e.g. an initial order book snapshot), so callers priming state
+// through this feed share the same 5 rps budget as the feed itself.
+func (f *Feed) RateLimiter() *rate.Limiter {
+	return f.limiter
+}
+
+func streamName(symbol, suffix string) string {
+	return strings.ToLower(symbol) + suffix
+}
+
+// reconnect tears down any existing connection and dials a fresh one built
+// from the current stream set. It is called every time a Subscribe* call
+// changes that set, and again (with backoff) whenever the read loop detects
+// a dropped connection.
+func (f *Feed) reconnect() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	streams := append([]string(nil), f.streams...)
+	f.generation++
+	gen := f.generation
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+	f.mu.Unlock()
+
+	if len(streams) == 0 {
+		return nil
+	}
+
+	url := fmt.Sprintf("%s/stream?streams=%s", f.baseURL, strings.Join(streams, "/"))
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return fmt.Errorf("binance: failed to dial combined stream: %w", err)
+	}
+
+	f.mu.Lock()
+	f.conn = conn
+	f.mu.Unlock()
+
+	go f.readLoop(conn, gen)
+	go f.pingLoop(conn, gen)
+	return nil
+}
+
+// readLoop consumes messages for one connection "generation" and reconnects
+// with backoff if the socket drops out from under a still-current feed.
+func (f *Feed) readLoop(conn *websocket.Conn, gen int) {
+	conn.SetReadDeadline(time.Now().Add(pongWaitTimeout))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(pongWaitTimeout))
+		return nil
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			f.handleDisconnect(gen, err)
+			return
+		}
+		f.dispatch(data)
+	}
+}
+
+// pingLoop sends client-side pings on an interval so a dead connection is
+// detected even if Binance's own server-side pings stop arriving.
+func (f *Feed) pingLoop(conn *websocket.Conn, gen int) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		f.mu.Lock()
+		current := f.generation == gen
+		f.mu.Unlock()
+		if !current {
+			return
+		}
+		if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *Feed) handleDisconnect(gen int, err error) {
+	f.mu.Lock()
+	current := f.generation == gen
+	closed := f.closed
+	f.mu.Unlock()
+	if !current || closed {
+		// Superseded by a new Subscribe call or an explicit Close; nothing to do.
+		return
+	}
+
+	log.Printf("binance: stream disconnected, reconnecting: %v", err)
+	delay := reconnectDelay
+	for {
+		time.Sleep(delay)
+		err := f.reconnect()
+		if err == nil {
+			return
+		}
+		f.mu.Lock()
+		done := f.closed || f.conn != nil
+		f.mu.Unlock()
+		if done {
+			// Closed, or a Subscribe call has already dialed a fresh connection.
+			return
+		}
+		if delay < maxReconnectDelay {
+			delay *= 2
+		}
+		log.Printf("binance: reconnect failed, retrying in %s: %v", delay, err)
+	}
+}
+
+func (f *Feed) dispatch(data []byte) {
+	var env combinedEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		log.Printf("binance: failed to decode stream envelope: %v", err)
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(env.Stream, "@trade"):
+		f.dispatchTrade(env.Data)
+	case strings.HasSuffix(env.Stream, "@bookTicker"):
+		f.dispatchBookTicker(env.Data)
+	case strings.Contains(env.Stream, "@kline_"):
+		f.dispatchKline(env.Data)
+	}
+}
+
+func (f *Feed) dispatchTrade(data json.RawMessage) {
+	f.mu.Lock()
+	handler := f.tradeHandler
+	f.mu.Unlock()
+	if handler == nil {
+		return
+	}
+
+	var msg tradeMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		log.Printf("binance: failed to decode trade: %v", err)
+		return
+	}
+
+	price, err := decimal.NewFromString(msg.Price)
+	if err != nil {
+		return
+	}
+	qty, err := decimal.NewFromString(msg.Quantity)
+	if err != nil {
+		return
+	}
+
+	handler(feed.Trade{
+		Symbol:    msg.Symbol,
+		Price:     price,
+		Quantity:  qty,
+		Timestamp: msg.TradeTime,
+	})
+}
+
+func (f *Feed) dispatchBookTicker(data json.RawMessage) {
+	f.mu.Lock()
+	handler := f.bookTickerHandler
+	f.mu.Unlock()
+	if handler == nil {
+		return
+	}
+
+	var msg bookTickerMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		log.Printf("binance: failed to decode book ticker: %v", err)
+		return
+	}
+
+	bidPrice, _ := decimal.NewFromString(msg.BidPrice)
+	bidQty, _ := decimal.NewFromString(msg.BidQty)
+	askPrice, _ := decimal.NewFromString(msg.AskPrice)
+	askQty, _ := decimal.NewFromString(msg.AskQty)
+
+	handler(feed.BookTicker{
+		Symbol:   msg.Symbol,
+		BidPrice: bidPrice,
+		BidQty:   bidQty,
+		AskPrice: askPrice,
+		AskQty:   askQty,
+	})
+}
+
+func (f *Feed) dispatchKline(data json.RawMessage) {
+	f.mu.Lock()
+	handler := f.klineHandler
+	f.mu.Unlock()
+	if handler == nil {
+		return
+	}
+
+	var msg klineMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		log.Printf("binance: failed to decode kline: %v", err)
+		return
+	}
+
+	open, _ := decimal.NewFromString(msg.Kline.Open)
+	high, _ := decimal.NewFromString(msg.Kline.High)
+	low, _ := decimal.NewFromString(msg.Kline.Low)
+	close, _ := decimal.NewFromString(msg.Kline.Close)
+
+	handler(feed.Kline{
+		Symbol:    msg.Symbol,
+		Interval:  msg.Kline.Interval,
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Close:     close,
+		Timestamp: msg.Kline.StartTime,
+		IsFinal:   msg.Kline.IsFinal,
+	})
+}
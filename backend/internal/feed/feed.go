@@ -0,0 +1,50 @@
+// Package feed defines the exchange-agnostic live market data subscription
+// surface that MarketService consumes. Concrete implementations (e.g.
+// feed/binance) translate a real exchange's wire protocol into these types.
+package feed
+
+import "github.com/shopspring/decimal"
+
+// Trade is a single executed trade tick for a symbol.
+type Trade struct {
+	Symbol    string
+	Price     decimal.Decimal
+	Quantity  decimal.Decimal
+	Timestamp int64 // unix millis
+}
+
+// BookTicker is the best bid/ask for a symbol, pushed on every change.
+type BookTicker struct {
+	Symbol   string
+	BidPrice decimal.Decimal
+	BidQty   decimal.Decimal
+	AskPrice decimal.Decimal
+	AskQty   decimal.Decimal
+}
+
+// Kline is a single candlestick update for a symbol/interval. IsFinal is true
+// once the interval has closed; otherwise it is the in-progress candle.
+type Kline struct {
+	Symbol    string
+	Interval  string
+	Open      decimal.Decimal
+	High      decimal.Decimal
+	Low       decimal.Decimal
+	Close     decimal.Decimal
+	Timestamp int64 // unix millis, start of the interval
+	IsFinal   bool
+}
+
+// MarketFeed is a live market data source. Implementations own their own
+// connection lifecycle (reconnect, keepalive, rate limiting) and deliver
+// ticks asynchronously to the supplied handlers until Close is called.
+type MarketFeed interface {
+	// SubscribeTrades streams executed trades for symbols.
+	SubscribeTrades(symbols []string, handler func(Trade)) error
+	// SubscribeBookTicker streams best bid/ask updates for symbols.
+	SubscribeBookTicker(symbols []string, handler func(BookTicker)) error
+	// SubscribeKlines streams candlestick updates for symbols at the given interval (e.g. "1m").
+	SubscribeKlines(symbols []string, interval string, handler func(Kline)) error
+	// Close tears down all subscriptions and the underlying connection.
+	Close() error
+}
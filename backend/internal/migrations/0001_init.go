@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"scope-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		Version: 1,
+		Name:    "init",
+		Body:    "AutoMigrate users, portfolios, portfolio_items, transactions",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&models.User{}, &models.Portfolio{}, &models.PortfolioItem{}, &models.Transaction{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&models.Transaction{}, &models.PortfolioItem{}, &models.Portfolio{}, &models.User{})
+		},
+	})
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import "gorm.io/gorm"
+
+func init() {
+	Register(Migration{
+		Version: 2,
+		Name:    "add_portfolio_cash_balance",
+		Body:    `ALTER TABLE portfolios ADD COLUMN IF NOT EXISTS cash_balance numeric NOT NULL DEFAULT 0; ALTER TABLE portfolios DROP COLUMN IF EXISTS cash_balance`,
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec(`ALTER TABLE portfolios ADD COLUMN IF NOT EXISTS cash_balance numeric NOT NULL DEFAULT 0`).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec(`ALTER TABLE portfolios DROP COLUMN IF EXISTS cash_balance`).Error
+		},
+	})
+}
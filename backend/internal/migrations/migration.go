@@ -0,0 +1,52 @@
+// Package migrations is a numbered, versioned schema-migration registry for
+// the Postgres side of the app (the models package). Each migration lives in
+// its own file (0001_init.go, 0002_..., ...) and registers itself from
+// init(), mirroring how the screener package registers strategies.
+package migrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a single versioned schema change. Body is the literal
+// SQL (or, for a non-SQL migration, a description precise enough to hash)
+// that Up/Down execute, recorded alongside them so Checksum can actually
+// detect drift: Go closures can't be hashed by reflection, so Body is the
+// part of the migration Checksum covers.
+type Migration struct {
+	Version int
+	Name    string
+	Body    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// Checksum detects drift between a migration's registered Body and the
+// version that was actually applied (e.g. someone edited an already-shipped
+// migration's SQL instead of adding a new one). It only covers Body, so a
+// migration file that changes Up/Down without updating Body to match won't
+// be caught -- keep the two in sync.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", m.Version, m.Name, m.Body)))
+	return hex.EncodeToString(sum[:])
+}
+
+var registry []Migration
+
+// Register adds a migration to the registry.
+func Register(m Migration) {
+	registry = append(registry, m)
+}
+
+// All returns every registered migration, sorted by version.
+func All() []Migration {
+	sorted := make([]Migration, len(registry))
+	copy(sorted, registry)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return sorted
+}
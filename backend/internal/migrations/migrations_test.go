@@ -0,0 +1,116 @@
+//go:build integration
+
+package migrations_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"scope-backend/internal/migrations"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// TestMigrationsUpDownIdempotent spins up a throwaway Postgres container and
+// walks every registered migration up then back down, asserting each
+// direction is idempotent: Up with nothing pending is a no-op, and Down
+// against an already-empty history is a no-op too.
+func TestMigrationsUpDownIdempotent(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:16-alpine"),
+		postgres.WithDatabase("scope_test"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).WithStartupTimeout(30*time.Second)),
+	)
+	if err != nil {
+		t.Fatalf("failed to start postgres container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("failed to get connection string: %v", err)
+	}
+
+	db, err := gorm.Open(gormpostgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to test container: %v", err)
+	}
+
+	if err := migrations.Up(db); err != nil {
+		t.Fatalf("first Up failed: %v", err)
+	}
+
+	pending, err := migrations.Pending(db)
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending migrations after Up, got %d", len(pending))
+	}
+
+	if err := migrations.Up(db); err != nil {
+		t.Fatalf("second Up (no-op) failed: %v", err)
+	}
+
+	steps := len(migrations.All())
+	if err := migrations.Down(db, steps); err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+	if err := migrations.Down(db, steps); err != nil {
+		t.Fatalf("second Down (no-op) failed: %v", err)
+	}
+}
+
+func TestMigrationsSquashRecordsBaseline(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:16-alpine"),
+		postgres.WithDatabase("scope_test"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).WithStartupTimeout(30*time.Second)),
+	)
+	if err != nil {
+		t.Fatalf("failed to start postgres container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("failed to get connection string: %v", err)
+	}
+
+	db, err := gorm.Open(gormpostgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to test container: %v", err)
+	}
+
+	if err := migrations.Up(db); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if err := migrations.Squash(db); err != nil {
+		t.Fatalf("Squash failed: %v", err)
+	}
+
+	pending, err := migrations.Pending(db)
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending migrations after Squash, got %d", len(pending))
+	}
+}
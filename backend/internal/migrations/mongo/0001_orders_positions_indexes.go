@@ -0,0 +1,27 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	gomongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	Register(Migration{
+		Version: 1,
+		Name:    "orders_positions_indexes",
+		Body:    "orders: {symbol: 1, created_at: 1}; positions: {symbol: 1}",
+		Up: func(ctx context.Context, db *gomongo.Database) error {
+			if _, err := db.Collection("orders").Indexes().CreateOne(ctx, gomongo.IndexModel{
+				Keys: bson.D{{Key: "symbol", Value: 1}, {Key: "created_at", Value: 1}},
+			}); err != nil {
+				return err
+			}
+			_, err := db.Collection("positions").Indexes().CreateOne(ctx, gomongo.IndexModel{
+				Keys: bson.D{{Key: "symbol", Value: 1}},
+			})
+			return err
+		},
+	})
+}
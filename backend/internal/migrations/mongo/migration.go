@@ -0,0 +1,52 @@
+// Package mongo is a numbered, versioned schema-migration registry for the
+// MongoDB side of the app (the services package), mirroring the Postgres
+// registry in internal/migrations: each migration lives in its own file
+// (0001_..., 0002_..., ...) and registers itself from init().
+package mongo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Migration is a single versioned Mongo schema change (index creation,
+// collection setup, etc.). Body is a description of what Up does -- e.g.
+// the index keys it creates -- precise enough to hash, recorded alongside
+// it so Checksum can actually detect drift: Go closures can't be hashed by
+// reflection, so Body is the part of the migration Checksum covers.
+type Migration struct {
+	Version int
+	Name    string
+	Body    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// Checksum detects drift between a migration's registered Body and the
+// version that was actually applied (e.g. someone edited an already-shipped
+// migration's index spec instead of adding a new one). It only covers Body,
+// so a migration file that changes Up without updating Body to match won't
+// be caught -- keep the two in sync.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", m.Version, m.Name, m.Body)))
+	return hex.EncodeToString(sum[:])
+}
+
+var registry []Migration
+
+// Register adds a migration to the registry.
+func Register(m Migration) {
+	registry = append(registry, m)
+}
+
+// All returns every registered migration, sorted by version.
+func All() []Migration {
+	sorted := make([]Migration, len(registry))
+	copy(sorted, registry)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return sorted
+}
@@ -0,0 +1,99 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func schemaMigrationsCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("schema_migrations")
+}
+
+func appliedByVersion(ctx context.Context, db *mongo.Database) (map[int]schemaMigration, error) {
+	cursor, err := schemaMigrationsCollection(db).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []schemaMigration
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	out := make(map[int]schemaMigration, len(rows))
+	for _, row := range rows {
+		out[row.Version] = row
+	}
+	return out, nil
+}
+
+// Pending reports which registered migrations have not yet been recorded in
+// schema_migrations.
+func Pending(ctx context.Context, db *mongo.Database) ([]Migration, error) {
+	done, err := appliedByVersion(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	var pending []Migration
+	for _, m := range All() {
+		if _, ok := done[m.Version]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration in order and records it in
+// schema_migrations. Calling Up with nothing pending is a no-op, and it is
+// safe to call on every startup.
+func Up(ctx context.Context, db *mongo.Database) error {
+	pending, err := Pending(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		if err := m.Up(ctx, db); err != nil {
+			return fmt.Errorf("mongo migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		_, err := schemaMigrationsCollection(db).InsertOne(ctx, schemaMigration{
+			Version:   m.Version,
+			Name:      m.Name,
+			Checksum:  m.Checksum(),
+			AppliedAt: time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record mongo migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		fmt.Printf("applied mongo migration %04d_%s\n", m.Version, m.Name)
+	}
+	return nil
+}
+
+// Status prints every registered migration and whether it has been applied,
+// flagging any whose recorded checksum no longer matches its registered
+// body.
+func Status(ctx context.Context, db *mongo.Database) error {
+	done, err := appliedByVersion(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	for _, m := range All() {
+		status := "pending"
+		if row, ok := done[m.Version]; ok {
+			status = fmt.Sprintf("applied at %s", row.AppliedAt.Format(time.RFC3339))
+			if row.Checksum != m.Checksum() {
+				status += " (checksum mismatch: migration body changed since it was applied)"
+			}
+		}
+		fmt.Printf("%04d_%s: %s\n", m.Version, m.Name, status)
+	}
+	return nil
+}
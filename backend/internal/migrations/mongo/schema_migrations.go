@@ -0,0 +1,12 @@
+package mongo
+
+import "time"
+
+// schemaMigration tracks which migrations have been applied, one document
+// per applied version in the "schema_migrations" collection.
+type schemaMigration struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	Checksum  string    `bson:"checksum"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
@@ -0,0 +1,180 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func ensureTable(db *gorm.DB) error {
+	return db.AutoMigrate(&SchemaMigration{})
+}
+
+func appliedByVersion(db *gorm.DB) (map[int]SchemaMigration, error) {
+	var rows []SchemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make(map[int]SchemaMigration, len(rows))
+	for _, row := range rows {
+		out[row.Version] = row
+	}
+	return out, nil
+}
+
+// Pending reports which registered migrations have not yet been recorded in
+// schema_migrations.
+func Pending(db *gorm.DB) ([]Migration, error) {
+	if err := ensureTable(db); err != nil {
+		return nil, fmt.Errorf("failed to ensure schema_migrations table: %w", err)
+	}
+
+	done, err := appliedByVersion(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	var pending []Migration
+	for _, m := range All() {
+		if _, ok := done[m.Version]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration in order, each inside its own
+// transaction, and records it in schema_migrations. Calling Up with nothing
+// pending is a no-op.
+func Up(db *gorm.DB) error {
+	pending, err := Pending(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   m.Version,
+				Name:      m.Name,
+				Checksum:  m.Checksum(),
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied migration %04d_%s\n", m.Version, m.Name)
+	}
+	return nil
+}
+
+// Down rolls back the `steps` most recently applied migrations, most recent
+// first. Calling Down against an empty history is a no-op.
+func Down(db *gorm.DB, steps int) error {
+	if err := ensureTable(db); err != nil {
+		return fmt.Errorf("failed to ensure schema_migrations table: %w", err)
+	}
+
+	var rows []SchemaMigration
+	if err := db.Order("version desc").Limit(steps).Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	byVersion := make(map[int]Migration, len(registry))
+	for _, m := range All() {
+		byVersion[m.Version] = m
+	}
+
+	for _, row := range rows {
+		m, ok := byVersion[row.Version]
+		if !ok {
+			return fmt.Errorf("migration %04d is recorded as applied but no longer registered", row.Version)
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return fmt.Errorf("migration %04d_%s down: %w", m.Version, m.Name, err)
+			}
+			return tx.Delete(&SchemaMigration{}, "version = ?", m.Version).Error
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted migration %04d_%s\n", m.Version, m.Name)
+	}
+	return nil
+}
+
+// Redo rolls back and reapplies the most recently applied migration.
+func Redo(db *gorm.DB) error {
+	if err := Down(db, 1); err != nil {
+		return err
+	}
+	return Up(db)
+}
+
+// Status prints every registered migration and whether it has been applied,
+// flagging any whose recorded checksum no longer matches its registered
+// body.
+func Status(db *gorm.DB) error {
+	if err := ensureTable(db); err != nil {
+		return fmt.Errorf("failed to ensure schema_migrations table: %w", err)
+	}
+
+	done, err := appliedByVersion(db)
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	for _, m := range All() {
+		status := "pending"
+		if row, ok := done[m.Version]; ok {
+			status = fmt.Sprintf("applied at %s", row.AppliedAt.Format(time.RFC3339))
+			if row.Checksum != m.Checksum() {
+				status += " (checksum mismatch: migration body changed since it was applied)"
+			}
+		}
+		fmt.Printf("%04d_%s: %s\n", m.Version, m.Name, status)
+	}
+	return nil
+}
+
+// Squash replaces schema_migrations with a baseline that marks every
+// registered migration as applied, without running any of them, so a fresh
+// deployment seeded from an up-to-date schema snapshot doesn't need to
+// replay history it already has. The baseline holds one row per registered
+// migration; the migrations themselves are not merged into one.
+func Squash(db *gorm.DB) error {
+	if err := ensureTable(db); err != nil {
+		return fmt.Errorf("failed to ensure schema_migrations table: %w", err)
+	}
+
+	all := All()
+	if len(all) == 0 {
+		return fmt.Errorf("no migrations registered to squash")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+			return err
+		}
+		for _, m := range all {
+			if err := tx.Create(&SchemaMigration{
+				Version:   m.Version,
+				Name:      m.Name,
+				Checksum:  m.Checksum(),
+				AppliedAt: time.Now(),
+			}).Error; err != nil {
+				return err
+			}
+		}
+		fmt.Printf("squashed %d migrations onto baseline %04d_%s\n", len(all), all[len(all)-1].Version, all[len(all)-1].Name)
+		return nil
+	})
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "time"
+
+// SchemaMigration tracks which migrations have been applied. It doesn't
+// embed models.Base: it predates every other table and must be safe to
+// create before any of them exist.
+type SchemaMigration struct {
+	Version   int `gorm:"primaryKey"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+func (SchemaMigration) TableName() string { return "schema_migrations" }
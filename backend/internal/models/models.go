@@ -30,8 +30,9 @@ type User struct {
 
 type Portfolio struct {
 	Base
-	UserID uuid.UUID `gorm:"index"`
-	Name   string
+	UserID      uuid.UUID `gorm:"index"`
+	Name        string
+	CashBalance decimal.Decimal `gorm:"type:numeric;default:0"`
 
 	// Relations
 	Items []PortfolioItem
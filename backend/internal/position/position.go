@@ -0,0 +1,72 @@
+// Package position is the shared weighted-average-cost position-accounting
+// logic services.PositionStore (live) and backtest.matchingEngine
+// (simulated) both fold fills through, so the two can't drift apart the way
+// they did when each carried its own copy.
+package position
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// State is one (symbol, leg)'s running position: Quantity is signed
+// (positive = long, negative = short) and AverageCost is the cost basis per
+// unit of whichever side is currently open, zero when flat.
+type State struct {
+	Quantity    decimal.Decimal
+	AverageCost decimal.Decimal
+}
+
+// ApplyFill folds one BUY/SELL fill into state and returns the updated
+// state along with the PnL (if any) realized by this fill.
+//
+// A fill in the same direction as the open side (or against a flat
+// position) rolls quantity and price into the weighted average cost. A
+// fill against an open position closes it, realizing PnL against
+// AverageCost; if the fill's quantity exceeds what was open, the excess
+// flips the position to the opposite side at the fill price. That flip is
+// what lets a futures short opened with SELL be closed with BUY instead of
+// being mistaken for the start of a new long.
+func ApplyFill(state State, side string, quantity, price decimal.Decimal) (State, decimal.Decimal, error) {
+	var signedQty decimal.Decimal
+	switch side {
+	case "BUY":
+		signedQty = quantity
+	case "SELL":
+		signedQty = quantity.Neg()
+	default:
+		return state, decimal.Zero, fmt.Errorf("unknown order side %q", side)
+	}
+
+	newQuantity := state.Quantity.Add(signedQty)
+
+	if state.Quantity.IsZero() || state.Quantity.Sign() == signedQty.Sign() {
+		filledNotional := state.AverageCost.Mul(state.Quantity.Abs())
+		state.Quantity = newQuantity
+		if state.Quantity.IsZero() {
+			state.AverageCost = decimal.Zero
+		} else {
+			state.AverageCost = filledNotional.Add(price.Mul(quantity)).Div(state.Quantity.Abs())
+		}
+		return state, decimal.Zero, nil
+	}
+
+	wasLong := state.Quantity.IsPositive()
+	closingQty := decimal.Min(quantity, state.Quantity.Abs())
+	var realized decimal.Decimal
+	if wasLong {
+		realized = price.Sub(state.AverageCost).Mul(closingQty)
+	} else {
+		realized = state.AverageCost.Sub(price).Mul(closingQty)
+	}
+
+	state.Quantity = newQuantity
+	switch {
+	case state.Quantity.IsZero():
+		state.AverageCost = decimal.Zero
+	case state.Quantity.IsPositive() != wasLong:
+		state.AverageCost = price
+	}
+	return state, realized, nil
+}
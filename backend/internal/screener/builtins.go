@@ -0,0 +1,120 @@
+package screener
+
+import (
+	"context"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func init() {
+	Register("momentum", func(deps Deps) ScreenerStrategy { return &momentumStrategy{} })
+	Register("oversold_rsi", func(deps Deps) ScreenerStrategy { return &oversoldRSIStrategy{} })
+	Register("sector_rotation", func(deps Deps) ScreenerStrategy { return &sectorRotationStrategy{} })
+	Register("custom", func(deps Deps) ScreenerStrategy { return &customStrategy{} })
+}
+
+// toFloat coerces the loosely-typed values that come out of bson.M query
+// params (which may arrive as string, float64, or int depending on the
+// caller) into a float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case int:
+		return float64(t), true
+	case string:
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	default:
+		return 0, false
+	}
+}
+
+// numericFromString builds an aggregation expression that strips a trailing
+// "%" from a string field (screener_results stores Change/P-E/etc. as
+// formatted strings) and parses what's left as a double, so numeric
+// comparisons can run via $expr.
+func numericFromString(fieldRef string) bson.M {
+	return bson.M{
+		"$toDouble": bson.M{
+			"$replaceAll": bson.M{
+				"input":       fieldRef,
+				"find":        "%",
+				"replacement": "",
+			},
+		},
+	}
+}
+
+// momentumStrategy surfaces tickers already tagged "momentum" by the
+// screener ingestion job, the same signal TradingService.RunAutomatedStrategy
+// looks for, with an optional stricter change-percent floor.
+type momentumStrategy struct{}
+
+func (s *momentumStrategy) Name() string { return "momentum" }
+
+func (s *momentumStrategy) Describe() StrategySchema {
+	return StrategySchema{
+		Name:        "momentum",
+		Description: "Tickers tagged by the momentum scan, optionally above a change-percent floor",
+		Params: map[string]ParamSchema{
+			"min_change_percent": {Type: "number", Description: "Minimum day change percent", Default: 3.0},
+		},
+	}
+}
+
+func (s *momentumStrategy) Filter(ctx context.Context, params bson.M) (bson.M, bson.D, error) {
+	filter := bson.M{"strategy": "momentum"}
+	if minChange, ok := toFloat(params["min_change_percent"]); ok {
+		filter["$expr"] = bson.M{"$gt": bson.A{numericFromString("$Change"), minChange}}
+	}
+	return filter, bson.D{{Key: "fetched_at", Value: -1}}, nil
+}
+
+// oversoldRSIStrategy surfaces tickers tagged "oversold_rsi" by the
+// ingestion job. RSI itself is computed upstream (screener_results carries
+// no raw indicator columns today), so this is a thin pass-through until an
+// IndicatorService lands.
+type oversoldRSIStrategy struct{}
+
+func (s *oversoldRSIStrategy) Name() string { return "oversold_rsi" }
+
+func (s *oversoldRSIStrategy) Describe() StrategySchema {
+	return StrategySchema{
+		Name:        "oversold_rsi",
+		Description: "Tickers tagged by the oversold-RSI scan",
+		Params:      map[string]ParamSchema{},
+	}
+}
+
+func (s *oversoldRSIStrategy) Filter(ctx context.Context, params bson.M) (bson.M, bson.D, error) {
+	return bson.M{"strategy": "oversold_rsi"}, bson.D{{Key: "fetched_at", Value: -1}}, nil
+}
+
+// sectorRotationStrategy surfaces tickers tagged "sector_rotation", narrowed
+// to a single sector when requested.
+type sectorRotationStrategy struct{}
+
+func (s *sectorRotationStrategy) Name() string { return "sector_rotation" }
+
+func (s *sectorRotationStrategy) Describe() StrategySchema {
+	return StrategySchema{
+		Name:        "sector_rotation",
+		Description: "Tickers tagged by the sector-rotation scan, optionally restricted to one sector",
+		Params: map[string]ParamSchema{
+			"sector": {Type: "string", Description: "GICS sector name, e.g. \"Technology\""},
+		},
+	}
+}
+
+func (s *sectorRotationStrategy) Filter(ctx context.Context, params bson.M) (bson.M, bson.D, error) {
+	filter := bson.M{"strategy": "sector_rotation"}
+	if sector, ok := params["sector"].(string); ok && sector != "" {
+		filter["Sector"] = sector
+	}
+	return filter, bson.D{{Key: "fetched_at", Value: -1}}, nil
+}
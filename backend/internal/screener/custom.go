@@ -0,0 +1,140 @@
+package screener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// allowedFields maps the DSL's field names to the bson field names on
+// ScreenerResult, so the query string can only ever reach columns we intend
+// to expose, never arbitrary document paths.
+var allowedFields = map[string]string{
+	"ticker":     "Ticker",
+	"sector":     "Sector",
+	"industry":   "Industry",
+	"pe":         "P/E",
+	"price":      "Price",
+	"change":     "Change",
+	"volume":     "Volume",
+	"market_cap": "Market Cap",
+}
+
+// numericFields are stored as formatted strings (e.g. "+3.50%") and need the
+// $expr/$toDouble treatment for ordered comparisons; eq/ne still compare the
+// raw string.
+var numericFields = map[string]bool{
+	"pe": true, "price": true, "change": true, "volume": true, "market_cap": true,
+}
+
+var allowedOps = map[string]string{
+	"lt": "$lt", "lte": "$lte", "gt": "$gt", "gte": "$gte", "eq": "$eq", "ne": "$ne",
+}
+
+// customStrategy compiles a small, safe filter DSL into a Mongo filter, e.g.
+// {"and":[{"pe":{"lt":15}},{"change":{"gt":0}}]}.
+type customStrategy struct{}
+
+func (s *customStrategy) Name() string { return "custom" }
+
+func (s *customStrategy) Describe() StrategySchema {
+	return StrategySchema{
+		Name:        "custom",
+		Description: `Generic filter DSL, e.g. {"and":[{"pe":{"lt":15}},{"change":{"gt":0}}]}`,
+		Params: map[string]ParamSchema{
+			"filter": {Type: "string", Description: "JSON filter expression"},
+		},
+	}
+}
+
+func (s *customStrategy) Filter(ctx context.Context, params bson.M) (bson.M, bson.D, error) {
+	raw, _ := params["filter"].(string)
+	if raw == "" {
+		return bson.M{}, nil, nil
+	}
+
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &root); err != nil {
+		return nil, nil, fmt.Errorf("invalid filter DSL: %w", err)
+	}
+
+	filter, err := compileDSLNode(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	return filter, nil, nil
+}
+
+func compileDSLNode(node map[string]interface{}) (bson.M, error) {
+	if andNodes, ok := node["and"]; ok {
+		return compileLogical("$and", andNodes)
+	}
+	if orNodes, ok := node["or"]; ok {
+		return compileLogical("$or", orNodes)
+	}
+
+	filter := bson.M{}
+	var exprs bson.A
+
+	for field, condRaw := range node {
+		mongoField, ok := allowedFields[field]
+		if !ok {
+			return nil, fmt.Errorf("filter DSL: field %q is not allowed", field)
+		}
+
+		cond, ok := condRaw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("filter DSL: field %q must map to an operator object", field)
+		}
+
+		for op, val := range cond {
+			mongoOp, ok := allowedOps[op]
+			if !ok {
+				return nil, fmt.Errorf("filter DSL: operator %q is not allowed", op)
+			}
+
+			if numericFields[field] && (mongoOp == "$lt" || mongoOp == "$lte" || mongoOp == "$gt" || mongoOp == "$gte") {
+				exprs = append(exprs, bson.M{mongoOp: bson.A{numericFromString("$" + mongoField), val}})
+				continue
+			}
+
+			ops, _ := filter[mongoField].(bson.M)
+			if ops == nil {
+				ops = bson.M{}
+			}
+			ops[mongoOp] = val
+			filter[mongoField] = ops
+		}
+	}
+
+	if len(exprs) > 0 {
+		filter["$expr"] = bson.M{"$and": exprs}
+	}
+
+	return filter, nil
+}
+
+func compileLogical(mongoOp string, nodesRaw interface{}) (bson.M, error) {
+	nodes, ok := nodesRaw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("filter DSL: %s must be an array", mongoOp)
+	}
+
+	compiled := make(bson.A, 0, len(nodes))
+	for _, n := range nodes {
+		nodeMap, ok := n.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("filter DSL: %s entries must be objects", mongoOp)
+		}
+
+		c, err := compileDSLNode(nodeMap)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, c)
+	}
+
+	return bson.M{mongoOp: compiled}, nil
+}
@@ -0,0 +1,99 @@
+package screener
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	Register("insider_cluster_buy", func(deps Deps) ScreenerStrategy {
+		return &insiderClusterBuyStrategy{deps: deps}
+	})
+}
+
+// insiderClusterBuyStrategy surfaces tickers where several distinct insiders
+// bought within a rolling window, something a single-collection query can't
+// express: it aggregates insider_trades by ticker, then keeps only the
+// tickers we actually have a live Redis price for before handing the result
+// back as a screener_results filter.
+type insiderClusterBuyStrategy struct {
+	deps Deps
+}
+
+func (s *insiderClusterBuyStrategy) Name() string { return "insider_cluster_buy" }
+
+func (s *insiderClusterBuyStrategy) Describe() StrategySchema {
+	return StrategySchema{
+		Name:        "insider_cluster_buy",
+		Description: "Tickers with several distinct insiders buying within a rolling window",
+		Params: map[string]ParamSchema{
+			"min_insiders": {Type: "number", Description: "Minimum distinct insiders buying", Default: 3},
+			"window_days":  {Type: "number", Description: "Rolling window, in days", Default: 30},
+		},
+	}
+}
+
+type insiderClusterRow struct {
+	Ticker string `bson:"_id"`
+	Count  int    `bson:"count"`
+}
+
+func (s *insiderClusterBuyStrategy) Filter(ctx context.Context, params bson.M) (bson.M, bson.D, error) {
+	if s.deps.Mongo == nil {
+		return nil, nil, fmt.Errorf("insider_cluster_buy requires a mongo connection")
+	}
+
+	minInsiders := 3
+	if v, ok := toFloat(params["min_insiders"]); ok {
+		minInsiders = int(v)
+	}
+	windowDays := 30
+	if v, ok := toFloat(params["window_days"]); ok {
+		windowDays = int(v)
+	}
+	since := time.Now().AddDate(0, 0, -windowDays)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"Transaction": bson.M{"$regex": "buy", "$options": "i"}}}},
+		{{Key: "$addFields", Value: bson.M{
+			"parsed_date": bson.M{"$dateFromString": bson.M{"dateString": "$Date", "onError": nil}},
+		}}},
+		{{Key: "$match", Value: bson.M{"parsed_date": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$Ticker",
+			"insiders": bson.M{"$addToSet": "$Owner"},
+		}}},
+		{{Key: "$project", Value: bson.M{"count": bson.M{"$size": "$insiders"}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gte": minInsiders}}}},
+	}
+
+	cursor, err := s.deps.Mongo.Collection("insider_trades").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, fmt.Errorf("insider_cluster_buy aggregation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []insiderClusterRow
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, nil, fmt.Errorf("insider_cluster_buy aggregation failed: %w", err)
+	}
+
+	tickers := make([]string, 0, len(rows))
+	for _, row := range rows {
+		// Only surface tickers the market feed actually tracks, so the
+		// screener never points the UI at a symbol with no live price.
+		if s.deps.Redis != nil {
+			exists, err := s.deps.Redis.Exists(ctx, fmt.Sprintf("market:price:%s", row.Ticker)).Result()
+			if err != nil || exists == 0 {
+				continue
+			}
+		}
+		tickers = append(tickers, row.Ticker)
+	}
+
+	return bson.M{"Ticker": bson.M{"$in": tickers}}, bson.D{{Key: "Change", Value: -1}}, nil
+}
@@ -0,0 +1,46 @@
+package screener
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Factory)
+)
+
+// Register adds a strategy factory under name. Built-in strategies register
+// themselves from an init() in the file that defines them.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[name] = factory
+}
+
+// Build instantiates every registered strategy against deps, keyed by name.
+// Called once per service construction; deps (Mongo/Redis handles) don't
+// change per request, only the params passed to Filter do.
+func Build(deps Deps) map[string]ScreenerStrategy {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	strategies := make(map[string]ScreenerStrategy, len(registry))
+	for name, factory := range registry {
+		strategies[name] = factory(deps)
+	}
+	return strategies
+}
+
+// Names returns every registered strategy name, sorted for stable output.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,50 @@
+// Package screener implements the screener strategy registry: a pluggable
+// set of ScreenerStrategy implementations, each turning request parameters
+// into a Mongo filter/sort pair run against the screener_results collection.
+package screener
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ParamSchema describes one parameter a strategy's Filter method accepts, so
+// the frontend can auto-render a filter UI from StrategySchema.
+type ParamSchema struct {
+	Type        string `json:"type"` // "number", "string", "bool"
+	Description string `json:"description"`
+	Default     any    `json:"default,omitempty"`
+}
+
+// StrategySchema is the self-description a strategy returns from Describe,
+// served by GET /api/v1/screener/strategies.
+type StrategySchema struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Params      map[string]ParamSchema `json:"params"`
+}
+
+// ScreenerStrategy turns request parameters into a Mongo filter/sort pair.
+// Strategies that need more than the screener_results collection (e.g.
+// insider_cluster_buy, which joins insider_trades against live Redis prices)
+// do that work inside Filter and return a filter that narrows
+// screener_results to the tickers they found.
+type ScreenerStrategy interface {
+	Name() string
+	Describe() StrategySchema
+	Filter(ctx context.Context, params bson.M) (bson.M, bson.D, error)
+}
+
+// Deps are the stores a strategy factory may need. Built-ins that only
+// filter screener_results ignore these; insider_cluster_buy uses both.
+type Deps struct {
+	Mongo *mongo.Database
+	Redis *redis.Client
+}
+
+// Factory builds a ScreenerStrategy bound to deps.
+type Factory func(deps Deps) ScreenerStrategy
@@ -1,7 +1,10 @@
 package server
 
 import (
+	"sync"
+
 	"scope-backend/internal/config"
+	"scope-backend/internal/feed"
 	"scope-backend/internal/services"
 	"scope-backend/internal/worker"
 
@@ -13,41 +16,110 @@ import (
 	"gorm.io/gorm"
 )
 
+// Wiring bundles every resource/service pointer that can be swapped out by a
+// restart-in-place. It is used both to build the initial Server and to
+// atomically replace its state once the setup wizard saves a new config.
+type Wiring struct {
+	DB                   *gorm.DB
+	MongoDB              *mongo.Database
+	RedisClient          *redis.Client
+	AuthService          *services.AuthService
+	MarketService        *services.MarketService
+	MarketFeed           feed.MarketFeed // nil when Market.Feed == "simulator"
+	NewsService          *services.NewsService
+	ScreenerService      *services.ScreenerService
+	InsiderService       *services.InsiderService
+	SectorService        *services.SectorService
+	FundamentalsService  *services.FundamentalsService
+	FuturesMarketService *services.MarketService      // nil unless Futures.Enabled; second venue for cross-venue strategies like "xfunding"
+	FundingRateService   *services.FundingRateService // nil unless Futures.Enabled
+}
+
 type Server struct {
 	cfg             *config.Config
-	db              *gorm.DB
-	mongoDB         *mongo.Database
 	router          *gin.Engine
-	authService     *services.AuthService
 	taskDistributor *worker.TaskDistributor
-	marketService   *services.MarketService
-	newsService     *services.NewsService
-	screenerService *services.ScreenerService
-	insiderService  *services.InsiderService
-	sectorService   *services.SectorService
-	redisClient     *redis.Client
+
+	// mu guards wiring: a successful /api/setup/restart swaps every
+	// connection/service pointer in one shot so in-flight requests never see
+	// a half-reinitialized server.
+	mu     sync.RWMutex
+	wiring Wiring
+
+	// Setup mode fields. setupMode is true when the server was started with
+	// no config.yaml on disk, or with --setup-token, and stays true until a
+	// successful restart. setupToken is generated once at startup and
+	// printed to the log so a fresh deployment can be configured entirely
+	// over HTTP.
+	setupMode  bool
+	setupToken string
+	configPath string
+
+	// beforeRestart, if set, runs just before a setup-triggered restart
+	// (e.g. to stop background tickers holding the old connections).
+	beforeRestart func()
+	// restartFn reconnects to the databases described by cfg and rebuilds
+	// the service wiring. It is supplied by main.go, which is the only place
+	// that knows how to construct each service.
+	restartFn func(cfg *config.Config) (Wiring, error)
+
+	// wsHub fans out price/order-book/mover updates to subscribed WebSocket
+	// clients.
+	wsHub *wsHub
 }
 
-func NewServer(cfg *config.Config, db *gorm.DB, mongoDB *mongo.Database, redisClient *redis.Client, taskDistributor *worker.TaskDistributor, authService *services.AuthService, marketService *services.MarketService, newsService *services.NewsService, screenerService *services.ScreenerService, insiderService *services.InsiderService, sectorService *services.SectorService) *Server {
+func NewServer(cfg *config.Config, db *gorm.DB, mongoDB *mongo.Database, redisClient *redis.Client, taskDistributor *worker.TaskDistributor, authService *services.AuthService, marketService *services.MarketService, newsService *services.NewsService, screenerService *services.ScreenerService, insiderService *services.InsiderService, sectorService *services.SectorService, fundamentalsService *services.FundamentalsService, setupMode bool, setupToken string) *Server {
 	s := &Server{
 		cfg:             cfg,
-		db:              db,
-		mongoDB:         mongoDB,
-		redisClient:     redisClient,
 		taskDistributor: taskDistributor,
-		authService:     authService,
-		marketService:   marketService,
-		newsService:     newsService,
-		screenerService: screenerService,
-		insiderService:  insiderService,
-		sectorService:   sectorService,
-		router:          gin.Default(),
+		wiring: Wiring{
+			DB:                  db,
+			MongoDB:             mongoDB,
+			RedisClient:         redisClient,
+			AuthService:         authService,
+			MarketService:       marketService,
+			NewsService:         newsService,
+			ScreenerService:     screenerService,
+			InsiderService:      insiderService,
+			SectorService:       sectorService,
+			FundamentalsService: fundamentalsService,
+		},
+		setupMode:  setupMode,
+		setupToken: setupToken,
+		configPath: config.DefaultConfigPath,
+		router:     gin.Default(),
+		wsHub:      newWSHub(),
+	}
+
+	if marketService != nil {
+		s.wsHub.subscribeRedis(s, "market:price:*", "market:")
+		s.wsHub.subscribeRedis(s, "market:orderbook:*", "market:")
+		s.wsHub.pollMovers(s)
 	}
 
 	s.SetupRoutes()
 	return s
 }
 
+// SetBeforeRestart registers a hook run immediately before a setup-triggered
+// restart reinitializes the server's wiring.
+func (s *Server) SetBeforeRestart(fn func()) {
+	s.beforeRestart = fn
+}
+
+// SetRestartFunc registers the function used to rebuild the server's wiring
+// from a freshly saved config. main.go supplies this since it owns the
+// constructors for every service.
+func (s *Server) SetRestartFunc(fn func(cfg *config.Config) (Wiring, error)) {
+	s.restartFn = fn
+}
+
+func (s *Server) current() Wiring {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.wiring
+}
+
 func (s *Server) SetupRoutes() {
 	v1 := s.router.Group("/api/v1")
 	{
@@ -61,6 +133,9 @@ func (s *Server) SetupRoutes() {
 		{
 			market.GET("/price/:symbol", s.handleGetPrice)
 			market.GET("/orderbook/:symbol", s.handleGetOrderBook)
+			market.GET("/orderbook/:symbol/depth", s.handleGetDepth)
+			market.GET("/orderbook/:symbol/spread", s.handleGetSpread)
+			market.GET("/ws", s.handleMarketWS)
 		}
 
 		news := v1.Group("/news")
@@ -72,6 +147,7 @@ func (s *Server) SetupRoutes() {
 		screener := v1.Group("/screener")
 		{
 			screener.GET("/", s.handleGetScreenerResults)
+			screener.GET("/strategies", s.handleGetScreenerStrategies)
 		}
 
 		insider := v1.Group("/insider")
@@ -84,16 +160,30 @@ func (s *Server) SetupRoutes() {
 			sector.GET("/", s.handleGetSectorPerformance)
 		}
 	}
+
+	// Setup-wizard routes. They exist on every instance but requireSetupToken
+	// answers 404 once the server isn't in setup mode, so a configured,
+	// running instance doesn't advertise the surface at all.
+	setup := s.router.Group("/api/setup")
+	setup.Use(s.requireSetupToken())
+	{
+		setup.POST("/test-db", s.handleSetupTestDB)
+		setup.POST("/test-mongo", s.handleSetupTestMongo)
+		setup.POST("/test-redis", s.handleSetupTestRedis)
+		setup.POST("/save", s.handleSetupSave)
+		setup.POST("/restart", s.handleSetupRestart)
+	}
 }
 
 func (s *Server) handleGetPrice(c *gin.Context) {
 	symbol := c.Param("symbol")
-	if s.marketService == nil {
+	marketService := s.current().MarketService
+	if marketService == nil {
 		c.JSON(503, gin.H{"error": "Market service unavailable"})
 		return
 	}
 
-	price, err := s.marketService.GetPrice(symbol)
+	price, err := marketService.GetPrice(symbol)
 	if err != nil {
 		c.JSON(404, gin.H{"error": err.Error()})
 		return
@@ -104,12 +194,37 @@ func (s *Server) handleGetPrice(c *gin.Context) {
 
 func (s *Server) handleGetOrderBook(c *gin.Context) {
 	symbol := c.Param("symbol")
-	if s.marketService == nil {
+	marketService := s.current().MarketService
+	if marketService == nil {
+		c.JSON(503, gin.H{"error": "Market service unavailable"})
+		return
+	}
+
+	book, err := marketService.GetOrderBook(symbol)
+	if err != nil {
+		c.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, book)
+}
+
+func (s *Server) handleGetDepth(c *gin.Context) {
+	symbol := c.Param("symbol")
+	marketService := s.current().MarketService
+	if marketService == nil {
 		c.JSON(503, gin.H{"error": "Market service unavailable"})
 		return
 	}
 
-	book, err := s.marketService.GetOrderBook(symbol)
+	levels := 20
+	if raw := c.Query("levels"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			levels = parsed
+		}
+	}
+
+	book, err := marketService.GetDepth(symbol, levels)
 	if err != nil {
 		c.JSON(404, gin.H{"error": err.Error()})
 		return
@@ -118,8 +233,26 @@ func (s *Server) handleGetOrderBook(c *gin.Context) {
 	c.JSON(200, book)
 }
 
+func (s *Server) handleGetSpread(c *gin.Context) {
+	symbol := c.Param("symbol")
+	marketService := s.current().MarketService
+	if marketService == nil {
+		c.JSON(503, gin.H{"error": "Market service unavailable"})
+		return
+	}
+
+	spread, err := marketService.GetSpread(symbol)
+	if err != nil {
+		c.JSON(404, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{"symbol": symbol, "spread": spread})
+}
+
 func (s *Server) handleGetLatestNews(c *gin.Context) {
-	if s.newsService == nil {
+	newsService := s.current().NewsService
+	if newsService == nil {
 		c.JSON(503, gin.H{"error": "News service unavailable"})
 		return
 	}
@@ -130,7 +263,7 @@ func (s *Server) handleGetLatestNews(c *gin.Context) {
 		limit = 20
 	}
 
-	news, err := s.newsService.GetLatestNews(c.Request.Context(), limit)
+	news, err := newsService.GetLatestNews(c.Request.Context(), limit)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -141,12 +274,13 @@ func (s *Server) handleGetLatestNews(c *gin.Context) {
 
 func (s *Server) handleGetNewsByTag(c *gin.Context) {
 	tag := c.Param("tag")
-	if s.newsService == nil {
+	newsService := s.current().NewsService
+	if newsService == nil {
 		c.JSON(503, gin.H{"error": "News service unavailable"})
 		return
 	}
 
-	news, err := s.newsService.GetNewsByTag(c.Request.Context(), tag, 20)
+	news, err := newsService.GetNewsByTag(c.Request.Context(), tag, 20)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -167,12 +301,13 @@ func (s *Server) handleRegister(c *gin.Context) {
 		return
 	}
 
-	if s.authService == nil {
+	authService := s.current().AuthService
+	if authService == nil {
 		c.JSON(503, gin.H{"error": "Database unavailable"})
 		return
 	}
 
-	user, err := s.authService.Register(req.Email, req.Password, req.FullName)
+	user, err := authService.Register(req.Email, req.Password, req.FullName)
 	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
@@ -192,12 +327,13 @@ func (s *Server) handleLogin(c *gin.Context) {
 		return
 	}
 
-	if s.authService == nil {
+	authService := s.current().AuthService
+	if authService == nil {
 		c.JSON(503, gin.H{"error": "Database unavailable"})
 		return
 	}
 
-	token, err := s.authService.Login(req.Email, req.Password)
+	token, err := authService.Login(req.Email, req.Password)
 	if err != nil {
 		c.JSON(401, gin.H{"error": err.Error()})
 		return
@@ -207,7 +343,8 @@ func (s *Server) handleLogin(c *gin.Context) {
 }
 
 func (s *Server) handleGetScreenerResults(c *gin.Context) {
-	if s.screenerService == nil {
+	screenerService := s.current().ScreenerService
+	if screenerService == nil {
 		c.JSON(503, gin.H{"error": "Screener service unavailable"})
 		return
 	}
@@ -219,7 +356,15 @@ func (s *Server) handleGetScreenerResults(c *gin.Context) {
 		limit = 50
 	}
 
-	results, err := s.screenerService.GetScreenerResults(c.Request.Context(), strategy, limit)
+	params := make(map[string]string)
+	for key, values := range c.Request.URL.Query() {
+		if key == "strategy" || key == "limit" || len(values) == 0 {
+			continue
+		}
+		params[key] = values[0]
+	}
+
+	results, err := screenerService.GetScreenerResults(c.Request.Context(), strategy, params, limit)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -228,8 +373,19 @@ func (s *Server) handleGetScreenerResults(c *gin.Context) {
 	c.JSON(200, results)
 }
 
+func (s *Server) handleGetScreenerStrategies(c *gin.Context) {
+	screenerService := s.current().ScreenerService
+	if screenerService == nil {
+		c.JSON(503, gin.H{"error": "Screener service unavailable"})
+		return
+	}
+
+	c.JSON(200, screenerService.ListStrategies())
+}
+
 func (s *Server) handleGetInsiderTrades(c *gin.Context) {
-	if s.insiderService == nil {
+	insiderService := s.current().InsiderService
+	if insiderService == nil {
 		c.JSON(503, gin.H{"error": "Insider service unavailable"})
 		return
 	}
@@ -240,7 +396,7 @@ func (s *Server) handleGetInsiderTrades(c *gin.Context) {
 		limit = 50
 	}
 
-	trades, err := s.insiderService.GetInsiderTrades(c.Request.Context(), limit)
+	trades, err := insiderService.GetInsiderTrades(c.Request.Context(), limit)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -250,12 +406,13 @@ func (s *Server) handleGetInsiderTrades(c *gin.Context) {
 }
 
 func (s *Server) handleGetSectorPerformance(c *gin.Context) {
-	if s.sectorService == nil {
+	sectorService := s.current().SectorService
+	if sectorService == nil {
 		c.JSON(503, gin.H{"error": "Sector service unavailable"})
 		return
 	}
 
-	performance, err := s.sectorService.GetSectorPerformance(c.Request.Context())
+	performance, err := sectorService.GetSectorPerformance(c.Request.Context())
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
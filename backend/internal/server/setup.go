@@ -0,0 +1,165 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+
+	"scope-backend/internal/config"
+	"scope-backend/internal/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GenerateSetupToken returns a random, URL-safe bearer token used to guard
+// the first-run setup endpoints. It is meant to be generated once at process
+// startup and printed to the log so an operator can copy it from the
+// container logs without shelling in.
+func GenerateSetupToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate setup token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// requireSetupToken guards every /api/setup/* route. Outside of setup mode
+// the surface is hidden entirely (404) rather than rejected (403/401), so a
+// configured instance doesn't even advertise that the endpoints exist.
+func (s *Server) requireSetupToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !s.setupMode {
+			c.JSON(404, gin.H{"error": "not found"})
+			c.Abort()
+			return
+		}
+
+		token := c.GetHeader("Authorization")
+		const prefix = "Bearer "
+		if len(token) <= len(prefix) || token[:len(prefix)] != prefix {
+			c.JSON(401, gin.H{"error": "missing bearer token"})
+			c.Abort()
+			return
+		}
+		token = token[len(prefix):]
+
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.setupToken)) != 1 {
+			c.JSON(401, gin.H{"error": "invalid setup token"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (s *Server) handleSetupTestDB(c *gin.Context) {
+	var cfg config.DatabaseConfig
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		c.JSON(200, gin.H{"ok": false, "error": err.Error()})
+		return
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+
+	c.JSON(200, gin.H{"ok": true})
+}
+
+func (s *Server) handleSetupTestMongo(c *gin.Context) {
+	var cfg config.MongoConfig
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	mongoDB, err := database.ConnectMongo(cfg)
+	if err != nil {
+		c.JSON(200, gin.H{"ok": false, "error": err.Error()})
+		return
+	}
+	mongoDB.Client().Disconnect(c.Request.Context())
+
+	c.JSON(200, gin.H{"ok": true})
+}
+
+func (s *Server) handleSetupTestRedis(c *gin.Context) {
+	var cfg config.RedisConfig
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	rdb := database.ConnectRedis(cfg)
+	defer rdb.Close()
+
+	if err := rdb.Ping(c.Request.Context()).Err(); err != nil {
+		c.JSON(200, gin.H{"ok": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{"ok": true})
+}
+
+func (s *Server) handleSetupSave(c *gin.Context) {
+	var cfg config.Config
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	if cfg.Server.Port == "" {
+		c.JSON(400, gin.H{"error": "server.port is required"})
+		return
+	}
+
+	if err := config.SaveConfig(&cfg, s.configPath); err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	s.cfg = &cfg
+	s.mu.Unlock()
+
+	c.JSON(200, gin.H{"ok": true})
+}
+
+// handleSetupRestart reconnects to every configured resource from the config
+// last saved via /api/setup/save and atomically swaps the server's wiring.
+// On failure the server keeps running against its previous (working)
+// connections so a bad config never bricks a live instance.
+func (s *Server) handleSetupRestart(c *gin.Context) {
+	if s.restartFn == nil {
+		c.JSON(500, gin.H{"error": "restart is not supported by this deployment"})
+		return
+	}
+
+	if s.beforeRestart != nil {
+		s.beforeRestart()
+	}
+
+	s.mu.RLock()
+	cfg := s.cfg
+	s.mu.RUnlock()
+
+	wiring, err := s.restartFn(cfg)
+	if err != nil {
+		c.JSON(500, gin.H{"error": fmt.Sprintf("restart failed, keeping previous wiring: %v", err)})
+		return
+	}
+
+	s.mu.Lock()
+	s.wiring = wiring
+	s.setupMode = false
+	s.mu.Unlock()
+
+	c.JSON(200, gin.H{"ok": true})
+}
@@ -0,0 +1,287 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"scope-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsMaxSubscriptions = 20
+	wsThrottle         = 100 * time.Millisecond
+	wsWriteWait        = 10 * time.Second
+	wsPongWait         = 60 * time.Second
+	wsPingPeriod       = (wsPongWait * 9) / 10
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// The frontend and API are served from different origins in dev; auth is
+	// handled by the JWT check below, not by Origin.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsSubscribeRequest is the client->server control frame.
+type wsSubscribeRequest struct {
+	Op       string   `json:"op"` // "subscribe" or "unsubscribe"
+	Channels []string `json:"channels"`
+}
+
+// wsFrame is the server->client data frame.
+type wsFrame struct {
+	Channel string          `json:"channel"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// wsClient is a single authenticated WebSocket connection and its channel
+// subscriptions. Updates are coalesced per channel on wsThrottle so a fast
+// feed can't flood a slow client.
+type wsClient struct {
+	conn   *websocket.Conn
+	hub    *wsHub
+	send   chan wsFrame
+	mu     sync.Mutex
+	topics map[string]bool
+}
+
+func (c *wsClient) subscribed(channel string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.topics[channel]
+}
+
+func (c *wsClient) subscribe(channels []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ch := range channels {
+		if len(c.topics) >= wsMaxSubscriptions {
+			break
+		}
+		c.topics[ch] = true
+	}
+}
+
+func (c *wsClient) unsubscribe(channels []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ch := range channels {
+		delete(c.topics, ch)
+	}
+}
+
+func (c *wsClient) readLoop() {
+	defer c.hub.unregister(c)
+
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		return nil
+	})
+
+	for {
+		var req wsSubscribeRequest
+		if err := c.conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		switch req.Op {
+		case "subscribe":
+			c.subscribe(req.Channels)
+		case "unsubscribe":
+			c.unsubscribe(req.Channels)
+		}
+	}
+}
+
+func (c *wsClient) writeLoop() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case frame, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteJSON(frame); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// wsHub fans Redis-sourced market updates out to subscribed clients,
+// coalescing per-channel updates on wsThrottle so a burst of ticks only ever
+// produces one frame per client per interval.
+type wsHub struct {
+	mu      sync.RWMutex
+	clients map[*wsClient]bool
+
+	pending   map[string]json.RawMessage
+	pendingMu sync.Mutex
+}
+
+func newWSHub() *wsHub {
+	h := &wsHub{
+		clients: make(map[*wsClient]bool),
+		pending: make(map[string]json.RawMessage),
+	}
+	go h.flushLoop()
+	return h
+}
+
+func (h *wsHub) register(c *wsClient) {
+	h.mu.Lock()
+	h.clients[c] = true
+	h.mu.Unlock()
+}
+
+func (h *wsHub) unregister(c *wsClient) {
+	h.mu.Lock()
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		close(c.send)
+	}
+	h.mu.Unlock()
+}
+
+// publish buffers the latest payload for a channel; flushLoop drains the
+// buffer on a fixed interval rather than fanning out on every call.
+func (h *wsHub) publish(channel string, data json.RawMessage) {
+	h.pendingMu.Lock()
+	h.pending[channel] = data
+	h.pendingMu.Unlock()
+}
+
+func (h *wsHub) flushLoop() {
+	ticker := time.NewTicker(wsThrottle)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		h.pendingMu.Lock()
+		batch := h.pending
+		h.pending = make(map[string]json.RawMessage)
+		h.pendingMu.Unlock()
+
+		if len(batch) == 0 {
+			continue
+		}
+
+		h.mu.RLock()
+		for channel, data := range batch {
+			frame := wsFrame{Channel: channel, Data: data}
+			for c := range h.clients {
+				if !c.subscribed(channel) {
+					continue
+				}
+				select {
+				case c.send <- frame:
+				default:
+					// Slow consumer: drop rather than block the hub.
+				}
+			}
+		}
+		h.mu.RUnlock()
+	}
+}
+
+// subscribeRedis forwards every message published on a Redis pub/sub pattern
+// into the hub, keyed by the exact channel name it arrived on (so
+// "market:price:AAPL" only reaches clients subscribed to "price:AAPL").
+func (h *wsHub) subscribeRedis(s *Server, pattern, stripPrefix string) {
+	redisClient := s.current().RedisClient
+	if redisClient == nil {
+		return
+	}
+
+	pubsub := redisClient.PSubscribe(s.current().MarketService.Ctx(), pattern)
+	go func() {
+		defer pubsub.Close()
+		for msg := range pubsub.Channel() {
+			channel := strings.TrimPrefix(msg.Channel, stripPrefix)
+			h.publish(channel, json.RawMessage(msg.Payload))
+		}
+	}()
+}
+
+// pollMovers periodically pushes top/worst movers snapshots, since those are
+// derived from the ZSET rather than published individually per update.
+func (h *wsHub) pollMovers(s *Server) {
+	ticker := time.NewTicker(wsThrottle)
+	go func() {
+		defer ticker.Stop()
+		for range ticker.C {
+			marketService := s.current().MarketService
+			if marketService == nil {
+				continue
+			}
+
+			if top, err := marketService.GetTopMovers(10); err == nil {
+				if data, err := json.Marshal(top); err == nil {
+					h.publish("movers.top", data)
+				}
+			}
+			if worst, err := marketService.GetWorstMovers(10); err == nil {
+				if data, err := json.Marshal(worst); err == nil {
+					h.publish("movers.worst", data)
+				}
+			}
+		}
+	}()
+}
+
+// handleMarketWS upgrades an authenticated request to a WebSocket and wires
+// it into the shared hub. Clients drive their own subscriptions with
+// {"op":"subscribe","channels":[...]} frames.
+func (s *Server) handleMarketWS(c *gin.Context) {
+	tokenString := c.Query("token")
+	if tokenString == "" {
+		if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			tokenString = strings.TrimPrefix(auth, "Bearer ")
+		}
+	}
+	if tokenString == "" {
+		c.JSON(401, gin.H{"error": "missing auth token"})
+		return
+	}
+	if _, err := utils.ParseToken(tokenString); err != nil {
+		c.JSON(401, gin.H{"error": "invalid auth token"})
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("websocket upgrade failed: %v", err)
+		return
+	}
+
+	client := &wsClient{
+		conn:   conn,
+		hub:    s.wsHub,
+		send:   make(chan wsFrame, 32),
+		topics: make(map[string]bool),
+	}
+
+	s.wsHub.register(client)
+	go client.writeLoop()
+	client.readLoop()
+}
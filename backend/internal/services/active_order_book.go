@@ -0,0 +1,65 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// ActiveOrder is one resting maker order a strategy instance is tracking so
+// it can cancel and reprice it later (see ActiveOrderBook).
+type ActiveOrder struct {
+	ID       string
+	Symbol   string
+	Side     string
+	Quantity decimal.Decimal
+	Price    decimal.Decimal
+}
+
+// ActiveOrderBook tracks each strategy instance's currently resting maker
+// orders in memory, keyed by an instance-scoped key (the same "id/symbol"
+// key StrategyStateStore uses), so cancel-and-reprice strategies like
+// "bollinger_pp" can cancel their own previous quotes without tracking
+// order IDs themselves. There is no real exchange behind these orders yet,
+// so "canceling" just removes the tracked entry.
+type ActiveOrderBook struct {
+	mu     sync.Mutex
+	orders map[string]map[string]*ActiveOrder // instanceKey -> orderID -> order
+}
+
+// NewActiveOrderBook builds an empty ActiveOrderBook.
+func NewActiveOrderBook() *ActiveOrderBook {
+	return &ActiveOrderBook{orders: make(map[string]map[string]*ActiveOrder)}
+}
+
+// Place starts tracking order under instanceKey.
+func (b *ActiveOrderBook) Place(instanceKey string, order *ActiveOrder) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.orders[instanceKey] == nil {
+		b.orders[instanceKey] = make(map[string]*ActiveOrder)
+	}
+	b.orders[instanceKey][order.ID] = order
+}
+
+// Cancel stops tracking orderID under instanceKey, returning the order that
+// was tracked (nil if none was).
+func (b *ActiveOrderBook) Cancel(instanceKey, orderID string) *ActiveOrder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	order := b.orders[instanceKey][orderID]
+	delete(b.orders[instanceKey], orderID)
+	return order
+}
+
+// CancelAll stops tracking every order under instanceKey, returning them.
+func (b *ActiveOrderBook) CancelAll(instanceKey string) []*ActiveOrder {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	orders := make([]*ActiveOrder, 0, len(b.orders[instanceKey]))
+	for _, order := range b.orders[instanceKey] {
+		orders = append(orders, order)
+	}
+	delete(b.orders, instanceKey)
+	return orders
+}
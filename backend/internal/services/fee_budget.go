@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// feeBudgetDocID is the single document FeeBudget reads/writes; the service
+// tracks one global daily budget rather than one per user or account.
+const feeBudgetDocID = "singleton"
+
+// FeeBudgetConfig bounds how much automated trading may spend in fees and
+// notional volume per rolling trading day.
+type FeeBudgetConfig struct {
+	DailyFeeBudgets map[string]decimal.Decimal // per-symbol fee cap; a symbol absent from this map is uncapped
+	DailyMaxVolume  decimal.Decimal            // total notional cap across all symbols; zero means uncapped
+}
+
+// feeBudgetState is the counters FeeBudget persists and rolls over every 24h.
+type feeBudgetState struct {
+	AccumulatedFeeStartedAt time.Time                  `bson:"accumulated_fee_started_at"`
+	AccumulatedFees         map[string]decimal.Decimal `bson:"accumulated_fees"`
+	AccumulatedVolume       decimal.Decimal            `bson:"accumulated_volume"`
+}
+
+type feeBudgetDoc struct {
+	ID string `bson:"_id"`
+	feeBudgetState
+}
+
+// FeeBudget is a persistent daily risk cap for automated trading: before
+// submitting an order, TradingService calls CheckAndReserve; once it fills,
+// RecordFill updates the running totals. Counters reset to midnight the
+// first time they're touched 24h after AccumulatedFeeStartedAt.
+type FeeBudget struct {
+	collection *mongo.Collection
+	cfg        FeeBudgetConfig
+
+	mu     sync.Mutex
+	state  feeBudgetState
+	loaded bool
+}
+
+// NewFeeBudget builds a FeeBudget backed by db's "fee_budget_state" collection.
+func NewFeeBudget(db *mongo.Database, cfg FeeBudgetConfig) *FeeBudget {
+	return &FeeBudget{
+		collection: db.Collection("fee_budget_state"),
+		cfg:        cfg,
+	}
+}
+
+// CheckAndReserve reports whether an order of notional value on symbol fits
+// within the remaining daily fee and volume budget. It does not itself
+// record the order; callers that proceed must call RecordFill once it fills.
+func (f *FeeBudget) CheckAndReserve(ctx context.Context, symbol string, notional decimal.Decimal) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.loadLocked(ctx); err != nil {
+		return false, err
+	}
+	f.rolloverLocked()
+
+	if budget, ok := f.cfg.DailyFeeBudgets[symbol]; ok {
+		if f.state.AccumulatedFees[symbol].GreaterThanOrEqual(budget) {
+			return false, nil
+		}
+	}
+
+	if !f.cfg.DailyMaxVolume.IsZero() {
+		projected := f.state.AccumulatedVolume.Add(notional)
+		if projected.GreaterThan(f.cfg.DailyMaxVolume) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// RecordFill adds fee/volume from a completed fill on symbol to today's
+// running totals and persists the result.
+func (f *FeeBudget) RecordFill(ctx context.Context, symbol string, fee, volume decimal.Decimal) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.loadLocked(ctx); err != nil {
+		return err
+	}
+	f.rolloverLocked()
+
+	if f.state.AccumulatedFees == nil {
+		f.state.AccumulatedFees = make(map[string]decimal.Decimal)
+	}
+	f.state.AccumulatedFees[symbol] = f.state.AccumulatedFees[symbol].Add(fee)
+	f.state.AccumulatedVolume = f.state.AccumulatedVolume.Add(volume)
+
+	return f.saveLocked(ctx)
+}
+
+// IsExhausted reports whether today's total notional volume has already hit
+// DailyMaxVolume. It is used by the TypeAutomatedTrading handler to skip an
+// entire tick up front, rather than letting every order inside it fail
+// CheckAndReserve one at a time.
+func (f *FeeBudget) IsExhausted(ctx context.Context) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.loadLocked(ctx); err != nil {
+		return false, err
+	}
+	f.rolloverLocked()
+
+	if f.cfg.DailyMaxVolume.IsZero() {
+		return false, nil
+	}
+	return f.state.AccumulatedVolume.GreaterThanOrEqual(f.cfg.DailyMaxVolume), nil
+}
+
+// rolloverLocked resets the counters to midnight of the current day once
+// AccumulatedFeeStartedAt is 24h or more in the past. f.mu must be held.
+func (f *FeeBudget) rolloverLocked() {
+	if !f.state.AccumulatedFeeStartedAt.IsZero() && time.Since(f.state.AccumulatedFeeStartedAt) < 24*time.Hour {
+		return
+	}
+
+	now := time.Now()
+	f.state = feeBudgetState{
+		AccumulatedFeeStartedAt: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		AccumulatedFees:         make(map[string]decimal.Decimal),
+		AccumulatedVolume:       decimal.Zero,
+	}
+}
+
+// loadLocked fetches the persisted state on first use of this FeeBudget.
+// f.mu must be held.
+func (f *FeeBudget) loadLocked(ctx context.Context) error {
+	if f.loaded {
+		return nil
+	}
+
+	var doc feeBudgetDoc
+	err := f.collection.FindOne(ctx, bson.M{"_id": feeBudgetDocID}).Decode(&doc)
+	switch {
+	case err == mongo.ErrNoDocuments:
+		f.state = feeBudgetState{AccumulatedFees: make(map[string]decimal.Decimal)}
+	case err != nil:
+		return fmt.Errorf("failed to load fee budget state: %w", err)
+	default:
+		f.state = doc.feeBudgetState
+		if f.state.AccumulatedFees == nil {
+			f.state.AccumulatedFees = make(map[string]decimal.Decimal)
+		}
+	}
+
+	f.loaded = true
+	return nil
+}
+
+// saveLocked upserts the current state. f.mu must be held.
+func (f *FeeBudget) saveLocked(ctx context.Context) error {
+	doc := feeBudgetDoc{ID: feeBudgetDocID, feeBudgetState: f.state}
+	opts := options.Replace().SetUpsert(true)
+	_, err := f.collection.ReplaceOne(ctx, bson.M{"_id": feeBudgetDocID}, doc, opts)
+	if err != nil {
+		return fmt.Errorf("failed to persist fee budget state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+)
+
+// fundingRateMaxMagnitude bounds the simulated funding rate's random walk to
+// roughly the extremes real perpetual-futures funding reaches (0.75% per
+// 8h funding interval).
+var fundingRateMaxMagnitude = decimal.NewFromFloat(0.0075)
+
+// fundingRateStep is the largest single-tick change StartSimulator applies.
+var fundingRateStep = decimal.NewFromFloat(0.0005)
+
+// FundingRateService tracks each symbol's current perpetual-futures funding
+// rate, for cross-exchange strategies (e.g. xfunding) that hedge a spot long
+// against a futures short to harvest it. There is no real futures feed yet,
+// so rates are simulated as a slow random walk around zero and cached in
+// Redis so a restart doesn't reset every symbol back to zero.
+type FundingRateService struct {
+	redisClient *redis.Client
+	ctx         context.Context
+}
+
+// NewFundingRateService builds a FundingRateService backed by redisClient.
+func NewFundingRateService(redisClient *redis.Client) *FundingRateService {
+	return &FundingRateService{redisClient: redisClient, ctx: context.Background()}
+}
+
+func fundingRateKey(symbol string) string { return fmt.Sprintf("funding:rate:%s", symbol) }
+
+// GetFundingRate returns symbol's last simulated funding rate, expressed as
+// a fraction (0.0005 == 0.05%). It defaults to zero for a symbol
+// StartSimulator hasn't ticked yet.
+func (s *FundingRateService) GetFundingRate(symbol string) (decimal.Decimal, error) {
+	val, err := s.redisClient.Get(s.ctx, fundingRateKey(symbol)).Result()
+	switch {
+	case err == redis.Nil:
+		return decimal.Zero, nil
+	case err != nil:
+		return decimal.Decimal{}, err
+	}
+	return decimal.NewFromString(val)
+}
+
+// StartSimulator perturbs every symbol's funding rate by a small random step
+// on every tick of interval, clamped to +/-fundingRateMaxMagnitude.
+func (s *FundingRateService) StartSimulator(symbols []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			for _, symbol := range symbols {
+				current, err := s.GetFundingRate(symbol)
+				if err != nil {
+					log.Printf("[funding-rate] %s: failed to read current rate: %v", symbol, err)
+					continue
+				}
+
+				delta := fundingRateStep.Mul(decimal.NewFromFloat(rand.Float64()*2 - 1))
+				next := decimal.Min(fundingRateMaxMagnitude, decimal.Max(fundingRateMaxMagnitude.Neg(), current.Add(delta)))
+
+				if err := s.redisClient.Set(s.ctx, fundingRateKey(symbol), next.String(), 0).Err(); err != nil {
+					log.Printf("[funding-rate] %s: failed to persist rate: %v", symbol, err)
+				}
+			}
+		}
+	}()
+}
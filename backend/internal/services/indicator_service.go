@@ -0,0 +1,105 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// IndicatorService computes technical indicators (currently SMA and
+// standard deviation, for Bollinger-band strategies) off MarketService's
+// kline history, so strategies don't each reimplement rolling-window math
+// over GetCandles.
+type IndicatorService struct {
+	market *MarketService
+}
+
+// NewIndicatorService builds an IndicatorService reading candles from market.
+func NewIndicatorService(market *MarketService) *IndicatorService {
+	return &IndicatorService{market: market}
+}
+
+// timeframeFor maps a polling interval to the nearest GetCandles timeframe
+// label, defaulting to "1m" for anything finer or unrecognized.
+func timeframeFor(interval time.Duration) string {
+	switch {
+	case interval >= 24*time.Hour:
+		return "24h"
+	case interval >= 12*time.Hour:
+		return "12h"
+	case interval >= time.Hour:
+		return "1h"
+	case interval >= 30*time.Minute:
+		return "30m"
+	case interval >= 15*time.Minute:
+		return "15m"
+	case interval >= 5*time.Minute:
+		return "5m"
+	default:
+		return "1m"
+	}
+}
+
+// closes returns the closing prices of the last window candles for symbol at
+// interval's timeframe, erroring if fewer than window candles are available.
+func (s *IndicatorService) closes(symbol string, interval time.Duration, window int) ([]decimal.Decimal, error) {
+	candles, err := s.market.GetCandles(symbol, timeframeFor(interval))
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) < window {
+		return nil, fmt.Errorf("not enough candle history for %s: have %d, need %d", symbol, len(candles), window)
+	}
+
+	recent := candles[len(candles)-window:]
+	closes := make([]decimal.Decimal, len(recent))
+	for i, c := range recent {
+		closes[i] = c.Close
+	}
+	return closes, nil
+}
+
+// SMA returns the simple moving average of the last window candle closes for
+// symbol at interval's timeframe.
+func (s *IndicatorService) SMA(symbol string, interval time.Duration, window int) (decimal.Decimal, error) {
+	closes, err := s.closes(symbol, interval, window)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return sma(closes), nil
+}
+
+// StdDev returns the population standard deviation of the last window
+// candle closes for symbol at interval's timeframe.
+func (s *IndicatorService) StdDev(symbol string, interval time.Duration, window int) (decimal.Decimal, error) {
+	closes, err := s.closes(symbol, interval, window)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return stdDev(closes), nil
+}
+
+func sma(values []decimal.Decimal) decimal.Decimal {
+	sum := decimal.Zero
+	for _, v := range values {
+		sum = sum.Add(v)
+	}
+	return sum.Div(decimal.NewFromInt(int64(len(values))))
+}
+
+// stdDev works in float64 rather than decimal.Decimal, which has no Sqrt,
+// the same tradeoff MarketService's own simulator makes for volatility math.
+func stdDev(values []decimal.Decimal) decimal.Decimal {
+	meanF, _ := sma(values).Float64()
+
+	var sumSquares float64
+	for _, v := range values {
+		vf, _ := v.Float64()
+		diff := vf - meanF
+		sumSquares += diff * diff
+	}
+	variance := sumSquares / float64(len(values))
+	return decimal.NewFromFloat(math.Sqrt(variance))
+}
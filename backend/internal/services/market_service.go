@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
+	"scope-backend/internal/feed"
+
 	"github.com/redis/go-redis/v9"
 	"github.com/shopspring/decimal"
 )
@@ -30,7 +34,13 @@ type MarketService struct {
 	ctx         context.Context
 	mu          sync.RWMutex
 	movers      map[string]StockPrice
-	candleCache map[string]map[string][]Candle // symbol -> timeframe -> candles
+	candleCache map[string]map[string][]Candle  // symbol -> timeframe -> candles
+	rngCache    map[string]map[string]*rand.Rand // symbol -> timeframe -> seeded RNG, for reproducible candle simulation
+	baseSeed    int64                            // combined with (symbol, timeframe) to derive each rngCache entry's seed
+
+	feedMu     sync.Mutex
+	marketFeed feed.MarketFeed
+	basePrices map[string]decimal.Decimal // symbol -> first observed feed price, for change% calc
 }
 
 type Candle struct {
@@ -49,15 +59,27 @@ type StockPrice struct {
 	Timestamp     time.Time       `json:"timestamp"`
 }
 
-func NewMarketService(redisClient *redis.Client) *MarketService {
+// NewMarketService builds a MarketService. baseSeed seeds the per-(symbol,
+// timeframe) RNGs GetCandles uses to simulate history, so the same baseSeed
+// always reproduces the same candles across restarts; see config.MarketConfig.Seed.
+func NewMarketService(redisClient *redis.Client, baseSeed int64) *MarketService {
 	return &MarketService{
 		redisClient: redisClient,
 		ctx:         context.Background(),
 		movers:      make(map[string]StockPrice),
 		candleCache: make(map[string]map[string][]Candle),
+		rngCache:    make(map[string]map[string]*rand.Rand),
+		baseSeed:    baseSeed,
 	}
 }
 
+// Ctx returns the service's background context, for callers (like the
+// WebSocket hub) that need to subscribe directly on the underlying Redis
+// client.
+func (s *MarketService) Ctx() context.Context {
+	return s.ctx
+}
+
 // GetPrice retrieves the latest price for a symbol from Redis
 func (s *MarketService) GetPrice(symbol string) (*StockPrice, error) {
 	key := fmt.Sprintf("market:price:%s", symbol)
@@ -107,35 +129,222 @@ func (s *MarketService) UpdatePrice(symbol string, name string, price decimal.De
 		Member: symbol,
 	})
 
+	// Publish so the WebSocket hub (and any other replica) can fan this tick
+	// out without polling Redis.
+	pipe.Publish(s.ctx, key, val)
+
 	_, err = pipe.Exec(s.ctx)
 	return err
 }
 
-// GetOrderBook retrieves the current order book from Redis
+// defaultDepthLevels bounds GetOrderBook's snapshot when a caller doesn't
+// care how deep the book goes (e.g. the REST handler).
+const defaultDepthLevels = 20
+
+// bidsKey and asksKey are the two Redis ZSETs backing a symbol's order book.
+// Each member encodes "price:amount" (so a level can be looked up and
+// removed exactly) and the score is the price, which is what lets
+// GetDepth/GetBestBidAsk/GetSpread read top-N levels in price order with a
+// single ZREVRANGEBYSCORE/ZRANGEBYSCORE call.
+func bidsKey(symbol string) string { return fmt.Sprintf("market:orderbook:%s:bids", symbol) }
+func asksKey(symbol string) string { return fmt.Sprintf("market:orderbook:%s:asks", symbol) }
+
+func levelMember(o Order) string { return o.Price.String() + ":" + o.Amount.String() }
+
+// BookDelta is a single order book level change, as published by
+// StreamBookDeltas. Amount is zero when the level was removed entirely.
+type BookDelta struct {
+	Side   string          `json:"side"` // "bid" or "ask"
+	Price  decimal.Decimal `json:"price"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// GetOrderBook retrieves a default-depth snapshot of the current order book.
 func (s *MarketService) GetOrderBook(symbol string) (*OrderBook, error) {
-	key := fmt.Sprintf("market:orderbook:%s", symbol)
-	val, err := s.redisClient.Get(context.Background(), key).Result()
+	return s.GetDepth(symbol, defaultDepthLevels)
+}
+
+// GetDepth retrieves the top `levels` price levels of each side of the book,
+// bids sorted highest-first and asks lowest-first.
+func (s *MarketService) GetDepth(symbol string, levels int) (*OrderBook, error) {
+	byScore := &redis.ZRangeBy{Min: "-inf", Max: "+inf", Count: int64(levels)}
+
+	bidZ, err := s.redisClient.ZRevRangeByScoreWithScores(s.ctx, bidsKey(symbol), byScore).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bids for %s: %w", symbol, err)
+	}
+	askZ, err := s.redisClient.ZRangeByScoreWithScores(s.ctx, asksKey(symbol), byScore).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asks for %s: %w", symbol, err)
+	}
+
+	bids, err := ordersFromZ(bidZ)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids for %s: %w", symbol, err)
+	}
+	asks, err := ordersFromZ(askZ)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks for %s: %w", symbol, err)
+	}
+
+	return &OrderBook{
+		Symbol:    symbol,
+		Bids:      bids,
+		Asks:      asks,
+		Timestamp: time.Now().UnixMilli(),
+	}, nil
+}
+
+// GetBestBidAsk returns the top-of-book bid and ask for symbol. Either may be
+// nil if that side of the book is currently empty.
+func (s *MarketService) GetBestBidAsk(symbol string) (bid *Order, ask *Order, err error) {
+	book, err := s.GetDepth(symbol, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(book.Bids) > 0 {
+		bid = &book.Bids[0]
+	}
+	if len(book.Asks) > 0 {
+		ask = &book.Asks[0]
+	}
+	return bid, ask, nil
+}
+
+// GetSpread returns the best ask minus the best bid for symbol.
+func (s *MarketService) GetSpread(symbol string) (decimal.Decimal, error) {
+	bid, ask, err := s.GetBestBidAsk(symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if bid == nil || ask == nil {
+		return decimal.Zero, fmt.Errorf("insufficient order book depth for symbol: %s", symbol)
+	}
+	return ask.Price.Sub(bid.Price), nil
+}
+
+// UpdateOrderBook replaces the stored levels for symbol with book, issuing
+// only the ZADD/ZREM pairs needed for levels that actually changed, then
+// streams the resulting deltas over Redis pub/sub via StreamBookDeltas.
+func (s *MarketService) UpdateOrderBook(symbol string, book *OrderBook) error {
+	bidDeltas, err := s.applyLevels(bidsKey(symbol), "bid", book.Bids)
+	if err != nil {
+		return fmt.Errorf("failed to apply bids for %s: %w", symbol, err)
+	}
+	askDeltas, err := s.applyLevels(asksKey(symbol), "ask", book.Asks)
+	if err != nil {
+		return fmt.Errorf("failed to apply asks for %s: %w", symbol, err)
+	}
+
+	return s.StreamBookDeltas(symbol, append(bidDeltas, askDeltas...))
+}
+
+// applyLevels diffs levels against what's currently stored under key and
+// issues the minimal set of ZREM (stale members)/ZADD (new or changed
+// members) operations, returning a BookDelta per level that actually moved.
+func (s *MarketService) applyLevels(key, side string, levels []Order) ([]BookDelta, error) {
+	existing, err := s.redisClient.ZRangeWithScores(s.ctx, key, 0, -1).Result()
 	if err != nil {
 		return nil, err
 	}
 
-	var book OrderBook
-	// Assuming we store JSON in Redis for simplicity
-	// In production, might use Sorted Sets for bids/asks
-	if err := json.Unmarshal([]byte(val), &book); err != nil {
+	prevMemberByPrice := make(map[string]string, len(existing))
+	for _, z := range existing {
+		if member, ok := z.Member.(string); ok {
+			if price, _, ok := splitLevelMember(member); ok {
+				prevMemberByPrice[price] = member
+			}
+		}
+	}
+
+	var deltas []BookDelta
+	seen := make(map[string]bool, len(levels))
+	pipe := s.redisClient.Pipeline()
+
+	for _, level := range levels {
+		priceStr := level.Price.String()
+		seen[priceStr] = true
+		member := levelMember(level)
+
+		if prev, ok := prevMemberByPrice[priceStr]; ok && prev == member {
+			continue // unchanged level
+		}
+		if prev, ok := prevMemberByPrice[priceStr]; ok {
+			pipe.ZRem(s.ctx, key, prev)
+		}
+		priceScore, _ := level.Price.Float64()
+		pipe.ZAdd(s.ctx, key, redis.Z{Score: priceScore, Member: member})
+		deltas = append(deltas, BookDelta{Side: side, Price: level.Price, Amount: level.Amount})
+	}
+
+	for priceStr, member := range prevMemberByPrice {
+		if seen[priceStr] {
+			continue
+		}
+		pipe.ZRem(s.ctx, key, member)
+		price, _ := decimal.NewFromString(priceStr)
+		deltas = append(deltas, BookDelta{Side: side, Price: price, Amount: decimal.Zero})
+	}
+
+	if _, err := pipe.Exec(s.ctx); err != nil {
 		return nil, err
 	}
-	return &book, nil
+	return deltas, nil
 }
 
-// UpdateOrderBook updates the order book in Redis
-func (s *MarketService) UpdateOrderBook(symbol string, book *OrderBook) error {
-	key := fmt.Sprintf("market:orderbook:%s", symbol)
-	data, err := json.Marshal(book)
+// StreamBookDeltas publishes level changes for symbol over the same Redis
+// pub/sub channel the WebSocket hub already bridges into the market feed, so
+// clients can apply incremental diffs instead of re-fetching a full
+// snapshot. It is a no-op when there is nothing to report.
+func (s *MarketService) StreamBookDeltas(symbol string, deltas []BookDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(deltas)
 	if err != nil {
 		return err
 	}
-	return s.redisClient.Set(context.Background(), key, data, 0).Err()
+
+	key := fmt.Sprintf("market:orderbook:%s", symbol)
+	return s.redisClient.Publish(s.ctx, key, data).Err()
+}
+
+// splitLevelMember splits a ZSET member of the form "price:amount" produced
+// by levelMember back into its two decimal strings.
+func splitLevelMember(member string) (priceStr, amountStr string, ok bool) {
+	idx := strings.LastIndex(member, ":")
+	if idx < 0 {
+		return "", "", false
+	}
+	return member[:idx], member[idx+1:], true
+}
+
+// ordersFromZ converts a ZSET range (as returned WITHSCORES) back into
+// Orders, recovering the amount from each member's encoded suffix.
+func ordersFromZ(z []redis.Z) ([]Order, error) {
+	orders := make([]Order, 0, len(z))
+	for _, item := range z {
+		member, ok := item.Member.(string)
+		if !ok {
+			continue
+		}
+		priceStr, amountStr, ok := splitLevelMember(member)
+		if !ok {
+			continue
+		}
+
+		price, err := decimal.NewFromString(priceStr)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := decimal.NewFromString(amountStr)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, Order{Price: price, Amount: amount})
+	}
+	return orders, nil
 }
 
 // GetTopMovers and GetWorstMovers logic is in market_movers.go (assumed based on previous reads)
@@ -143,8 +352,95 @@ func (s *MarketService) UpdateOrderBook(symbol string, book *OrderBook) error {
 // Wait, `market_movers.go` exists. I should not duplicate logic if it's there.
 // But this file `market_service.go` seems to be the main one.
 
-// StartMarketSimulator simulates real-time price updates for demo purposes
-// In production, this would be replaced by a real feed handler (e.g., WebSocket to Polygon.io/Alpaca)
+// StartFeed subscribes to trades, book ticker, and 1m klines for symbols on
+// f, fanning each tick into UpdatePrice/UpdateOrderBook and the candle cache
+// used by GetCandles. Callers should fall back to StartMarketSimulator if
+// StartFeed returns an error (e.g. the exchange is unreachable at boot).
+func (s *MarketService) StartFeed(f feed.MarketFeed, symbols []string) error {
+	s.feedMu.Lock()
+	s.marketFeed = f
+	s.basePrices = make(map[string]decimal.Decimal)
+	s.feedMu.Unlock()
+
+	if err := f.SubscribeTrades(symbols, s.ingestTrade); err != nil {
+		return fmt.Errorf("failed to subscribe to trades: %w", err)
+	}
+	if err := f.SubscribeBookTicker(symbols, s.ingestBookTicker); err != nil {
+		return fmt.Errorf("failed to subscribe to book ticker: %w", err)
+	}
+	if err := f.SubscribeKlines(symbols, "1m", s.ingestKline); err != nil {
+		return fmt.Errorf("failed to subscribe to klines: %w", err)
+	}
+	return nil
+}
+
+// ingestTrade turns a live feed.Trade into the same UpdatePrice call the
+// simulator would make, tracking each symbol's first observed price so we
+// can report a session change percent.
+func (s *MarketService) ingestTrade(t feed.Trade) {
+	s.feedMu.Lock()
+	base, ok := s.basePrices[t.Symbol]
+	if !ok {
+		base = t.Price
+		s.basePrices[t.Symbol] = base
+	}
+	s.feedMu.Unlock()
+
+	changePct := decimal.Zero
+	if !base.IsZero() {
+		changePct = t.Price.Sub(base).Div(base).Mul(decimal.NewFromInt(100))
+	}
+
+	if err := s.UpdatePrice(t.Symbol, t.Symbol, t.Price, changePct); err != nil {
+		log.Printf("Error updating price for %s from feed: %v", t.Symbol, err)
+	}
+}
+
+// ingestBookTicker turns a live feed.BookTicker into the single-level
+// top-of-book snapshot UpdateOrderBook expects.
+func (s *MarketService) ingestBookTicker(bt feed.BookTicker) {
+	book := &OrderBook{
+		Symbol:    bt.Symbol,
+		Bids:      []Order{{Price: bt.BidPrice, Amount: bt.BidQty}},
+		Asks:      []Order{{Price: bt.AskPrice, Amount: bt.AskQty}},
+		Timestamp: time.Now().UnixMilli(),
+	}
+	if err := s.UpdateOrderBook(bt.Symbol, book); err != nil {
+		log.Printf("Error updating order book for %s from feed: %v", bt.Symbol, err)
+	}
+}
+
+// ingestKline aggregates a live feed.Kline into the same candleCache
+// GetCandles reads from, so live and simulated candles are indistinguishable
+// to callers.
+func (s *MarketService) ingestKline(k feed.Kline) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.candleCache[k.Symbol]; !ok {
+		s.candleCache[k.Symbol] = make(map[string][]Candle)
+	}
+
+	candle := Candle{
+		Timestamp: k.Timestamp,
+		Open:      k.Open,
+		High:      k.High,
+		Low:       k.Low,
+		Close:     k.Close,
+	}
+
+	cached := s.candleCache[k.Symbol][k.Interval]
+	if len(cached) > 0 && cached[len(cached)-1].Timestamp == candle.Timestamp {
+		cached[len(cached)-1] = candle
+	} else {
+		cached = append(cached, candle)
+	}
+	s.candleCache[k.Symbol][k.Interval] = cached
+}
+
+// StartMarketSimulator simulates real-time price updates for demo purposes.
+// It is the fallback used when no MarketFeed is configured (config.Market.Feed == "simulator"),
+// or when a configured feed fails to start.
 func (s *MarketService) StartMarketSimulator() {
 	ticker := time.NewTicker(1 * time.Second)
 
@@ -204,78 +500,131 @@ func (s *MarketService) StartMarketSimulator() {
 	}()
 }
 
-// GetCandles generates simulated candlestick data
-func (s *MarketService) GetCandles(symbol string, timeframe string) ([]Candle, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Initialize nested map if not exists
-	if _, ok := s.candleCache[symbol]; !ok {
-		s.candleCache[symbol] = make(map[string][]Candle)
-	}
-
-	// 1. Determine interval and count
-	var interval time.Duration
-	var count int
-
+// intervalAndCount returns the candle interval and backfill count for
+// timeframe as of now. now is only consulted for "ytd", which counts
+// elapsed weeks since the start of now's year.
+func intervalAndCount(timeframe string, now time.Time) (time.Duration, int) {
 	switch timeframe {
 	case "1m":
-		interval = time.Minute
-		count = 60
+		return time.Minute, 60
 	case "5m":
-		interval = 5 * time.Minute
-		count = 60
+		return 5 * time.Minute, 60
 	case "15m":
-		interval = 15 * time.Minute
-		count = 60
+		return 15 * time.Minute, 60
 	case "30m":
-		interval = 30 * time.Minute
-		count = 48
+		return 30 * time.Minute, 48
 	case "1h":
-		interval = time.Hour
-		count = 24
+		return time.Hour, 24
 	case "12h":
-		interval = 12 * time.Hour
-		count = 30
+		return 12 * time.Hour, 30
 	case "24h":
-		interval = 24 * time.Hour
-		count = 30
+		return 24 * time.Hour, 30
 	case "3d":
-		interval = 72 * time.Hour // 3 days
-		count = 30
+		return 72 * time.Hour, 30 // 3 days
 	case "1w":
-		interval = 7 * 24 * time.Hour
-		count = 52
+		return 7 * 24 * time.Hour, 52
 	case "1mo":
-		interval = 30 * 24 * time.Hour
-		count = 24
+		return 30 * 24 * time.Hour, 24
 	case "3mo":
-		interval = 90 * 24 * time.Hour
-		count = 12
+		return 90 * 24 * time.Hour, 12
 	case "6mo":
-		interval = 7 * 24 * time.Hour // Weekly candles for 6 months
-		count = 26
+		return 7 * 24 * time.Hour, 26 // Weekly candles for 6 months
 	case "1y":
-		interval = 7 * 24 * time.Hour // Weekly candles for 1 year
-		count = 52
+		return 7 * 24 * time.Hour, 52 // Weekly candles for 1 year
 	case "ytd":
-		interval = 7 * 24 * time.Hour // Weekly candles for YTD
-		// Calculate weeks since start of year
-		ytd := time.Now().Sub(time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC))
-		count = int(ytd.Hours() / (24 * 7))
+		interval := 7 * 24 * time.Hour // Weekly candles for YTD
+		ytd := now.Sub(time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC))
+		count := int(ytd.Hours() / (24 * 7))
 		if count < 1 {
 			count = 1
 		}
+		return interval, count
 	default:
-		interval = 5 * time.Minute
-		count = 60
+		return 5 * time.Minute, 60
+	}
+}
+
+// generateCandleHistory backfills the candles for timeframe ending at now,
+// walking the price backwards from anchorPrice. It is a pure function of its
+// arguments (including rng's seed), which is what lets testdata/candles
+// golden vectors assert its output byte-for-byte; see market_service_test.go.
+func generateCandleHistory(rng *rand.Rand, timeframe string, anchorPrice decimal.Decimal, now time.Time) []Candle {
+	interval, count := intervalAndCount(timeframe, now)
+
+	candles := make([]Candle, count)
+	ts := now.Truncate(interval)
+	price := anchorPrice
+
+	for i := count - 1; i >= 0; i-- {
+		volatility := 0.002
+		if interval > time.Hour {
+			volatility = 0.01
+		}
+
+		changePct := (rng.Float64() - 0.5) * 2 * volatility
+		open := price.Div(decimal.NewFromFloat(1 + changePct))
+		high := decimal.Max(open, price).Mul(decimal.NewFromFloat(1 + rng.Float64()*volatility))
+		low := decimal.Min(open, price).Mul(decimal.NewFromFloat(1 - rng.Float64()*volatility))
+
+		candles[i] = Candle{
+			Timestamp: ts.UnixMilli(),
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Close:     price,
+		}
+
+		price = open
+		ts = ts.Add(-interval)
+	}
+
+	return candles
+}
+
+// seedFor derives the RNG seed for (symbol, timeframe) from baseSeed, so
+// different pairs don't share identical random streams even under the same
+// base seed.
+func seedFor(baseSeed int64, symbol, timeframe string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(symbol))
+	h.Write([]byte{0})
+	h.Write([]byte(timeframe))
+	return int64(h.Sum64()) ^ baseSeed
+}
+
+// rngFor returns the seeded *rand.Rand for (symbol, timeframe), creating and
+// caching it on first use. Callers must hold s.mu.
+func (s *MarketService) rngFor(symbol, timeframe string) *rand.Rand {
+	if _, ok := s.rngCache[symbol]; !ok {
+		s.rngCache[symbol] = make(map[string]*rand.Rand)
+	}
+	if rng, ok := s.rngCache[symbol][timeframe]; ok {
+		return rng
+	}
+
+	rng := rand.New(rand.NewSource(seedFor(s.baseSeed, symbol, timeframe)))
+	s.rngCache[symbol][timeframe] = rng
+	return rng
+}
+
+// GetCandles generates simulated candlestick data
+func (s *MarketService) GetCandles(symbol string, timeframe string) ([]Candle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Initialize nested map if not exists
+	if _, ok := s.candleCache[symbol]; !ok {
+		s.candleCache[symbol] = make(map[string][]Candle)
 	}
 
+	now := time.Now()
+	interval, _ := intervalAndCount(timeframe, now)
+	rng := s.rngFor(symbol, timeframe)
+
 	// 2. Check cache
 	cached, exists := s.candleCache[symbol][timeframe]
 	if !exists || len(cached) == 0 {
-		// Generate initial history
-		// Get current price to anchor the simulation
+		// Generate initial history, anchored to the current price
 		currentPrice, err := s.GetPrice(symbol)
 		if err != nil {
 			// If not found, use a default
@@ -283,38 +632,7 @@ func (s *MarketService) GetCandles(symbol string, timeframe string) ([]Candle, e
 			currentPrice = &StockPrice{Price: price}
 		}
 
-		candles := make([]Candle, count)
-		now := time.Now().Truncate(interval)
-		price := currentPrice.Price
-
-		for i := count - 1; i >= 0; i-- {
-			volatility := 0.002
-			if interval > time.Hour {
-				volatility = 0.01
-			}
-
-			// Deterministic seed based on timestamp for historical stability
-			// But for initial generation, random is fine as long as we cache it.
-			// However, to be consistent if server restarts, we could seed.
-			// For now, random walk backwards.
-
-			changePct := (rand.Float64() - 0.5) * 2 * volatility
-			open := price.Div(decimal.NewFromFloat(1 + changePct))
-			high := decimal.Max(open, price).Mul(decimal.NewFromFloat(1 + rand.Float64()*volatility))
-			low := decimal.Min(open, price).Mul(decimal.NewFromFloat(1 - rand.Float64()*volatility))
-
-			candles[i] = Candle{
-				Timestamp: now.UnixMilli(),
-				Open:      open,
-				High:      high,
-				Low:       low,
-				Close:     price,
-			}
-
-			price = open
-			now = now.Add(-interval)
-		}
-
+		candles := generateCandleHistory(rng, timeframe, currentPrice.Price, now)
 		s.candleCache[symbol][timeframe] = candles
 		return candles, nil
 	}
@@ -322,7 +640,6 @@ func (s *MarketService) GetCandles(symbol string, timeframe string) ([]Candle, e
 	// 3. Update cached candles
 	// Check if we need to start a new candle or update the latest one
 	lastCandle := &cached[len(cached)-1]
-	now := time.Now()
 	currentIntervalStart := now.Truncate(interval).UnixMilli()
 
 	if currentIntervalStart > lastCandle.Timestamp {
@@ -348,7 +665,7 @@ func (s *MarketService) GetCandles(symbol string, timeframe string) ([]Candle, e
 	} else {
 		// Update current candle with live simulation
 		volatility := 0.0005 // Smaller volatility for live updates
-		changePct := (rand.Float64() - 0.5) * 2 * volatility
+		changePct := (rng.Float64() - 0.5) * 2 * volatility
 		newClose := lastCandle.Close.Mul(decimal.NewFromFloat(1 + changePct))
 
 		// Update High/Low
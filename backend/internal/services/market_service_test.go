@@ -0,0 +1,133 @@
+package services
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// update regenerates every vector's Expected candles from
+// generateCandleHistory's actual output instead of asserting against it:
+// `go test ./internal/services/... -run TestCandleVectors -update`.
+var update = flag.Bool("update", false, "regenerate expected candles for each vector from generateCandleHistory's actual output")
+
+const candleVectorsDir = "testdata/candles"
+
+// candleVector pins one (symbol, timeframe, seed, anchorPrice, now) input to
+// the []Candle generateCandleHistory must produce, so contributors changing
+// candle math get a byte-for-byte diff instead of a silent behavior change.
+// Expected is kept as raw JSON (like conformance.Vector.ExpectedJSON) and
+// compared via jsonEqual rather than unmarshaled into []Candle, since
+// decimal.Decimal values that are numerically equal can have different
+// internal representations after a JSON round-trip.
+type candleVector struct {
+	Symbol      string          `json:"symbol"`
+	Timeframe   string          `json:"timeframe"`
+	Seed        int64           `json:"seed"`
+	AnchorPrice decimal.Decimal `json:"anchor_price"`
+	Now         time.Time       `json:"now"`
+	Expected    json.RawMessage `json:"expected"`
+}
+
+// loadCandleVectors reads every *.json file in dir into a candleVector, keyed
+// by file name.
+func loadCandleVectors(dir string) (map[string]candleVector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make(map[string]candleVector, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var v candleVector
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		vectors[entry.Name()] = v
+	}
+	return vectors, nil
+}
+
+// saveCandleVector writes v back to dir/name, used by `go test -update` to
+// regenerate Expected from generateCandleHistory's actual output.
+func saveCandleVector(dir, name string, v candleVector) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	return os.WriteFile(filepath.Join(dir, name), data, 0o644)
+}
+
+// jsonEqual reports whether actual and expected marshal to the same JSON
+// value, ignoring key order and decimal.Decimal's internal representation
+// (which can differ between two numerically-equal values after a JSON
+// round-trip).
+func jsonEqual(actual, expected []byte) bool {
+	if len(expected) == 0 {
+		return false
+	}
+	var a, b any
+	if err := json.Unmarshal(actual, &a); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(expected, &b); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// TestCandleVectors replays every vector under testdata/candles through
+// generateCandleHistory, seeded exactly as rngFor would derive it, and
+// asserts the result's JSON encoding deep-equals Expected. Run with -update
+// to regenerate Expected after a deliberate change to the candle simulation.
+func TestCandleVectors(t *testing.T) {
+	vectors, err := loadCandleVectors(candleVectorsDir)
+	if err != nil {
+		t.Fatalf("failed to load vectors: %v", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("no candle vectors found under " + candleVectorsDir)
+	}
+
+	for name, v := range vectors {
+		name, v := name, v
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(seedFor(v.Seed, v.Symbol, v.Timeframe)))
+			actual := generateCandleHistory(rng, v.Timeframe, v.AnchorPrice, v.Now)
+
+			actualJSON, err := json.Marshal(actual)
+			if err != nil {
+				t.Fatalf("failed to marshal actual candles: %v", err)
+			}
+
+			if *update {
+				v.Expected = actualJSON
+				if err := saveCandleVector(candleVectorsDir, name, v); err != nil {
+					t.Fatalf("failed to update vector: %v", err)
+				}
+				return
+			}
+
+			if !jsonEqual(actualJSON, v.Expected) {
+				t.Fatalf("vector %s: got %s, want %s", name, actualJSON, v.Expected)
+			}
+		})
+	}
+}
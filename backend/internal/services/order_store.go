@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OrderStore persists every TradeOrder TradingService's ExecuteOrder family
+// of methods simulates a fill for, so order history survives a restart and
+// PositionStore can be rebuilt from it if it's ever dropped.
+type OrderStore struct {
+	collection *mongo.Collection
+}
+
+// NewOrderStore builds an OrderStore backed by db's "orders" collection.
+func NewOrderStore(db *mongo.Database) *OrderStore {
+	return &OrderStore{collection: db.Collection("orders")}
+}
+
+// Record persists order. TradingService calls it once per simulated fill,
+// immediately after building the TradeOrder.
+func (s *OrderStore) Record(ctx context.Context, order TradeOrder) error {
+	if _, err := s.collection.InsertOne(ctx, order); err != nil {
+		return fmt.Errorf("failed to persist order for %s: %w", order.Symbol, err)
+	}
+	return nil
+}
+
+// Count returns how many orders have been persisted in total, for
+// TradingService to log on startup recovery.
+func (s *OrderStore) Count(ctx context.Context) (int64, error) {
+	count, err := s.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count persisted orders: %w", err)
+	}
+	return count, nil
+}
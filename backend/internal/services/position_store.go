@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"scope-backend/internal/position"
+	"scope-backend/internal/strategy"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// positionState is the counters PositionStore tracks per position and rolls
+// forward on every fill. Quantity is signed: positive is long, negative is
+// short.
+type positionState struct {
+	Quantity        decimal.Decimal `bson:"quantity"`
+	AverageCost     decimal.Decimal `bson:"average_cost"`
+	RealizedPnL     decimal.Decimal `bson:"realized_pnl"`
+	AccumulatedFees decimal.Decimal `bson:"accumulated_fees"`
+	UpdatedAt       time.Time       `bson:"updated_at"`
+}
+
+// Position is one symbol's running position, derived fill-by-fill from
+// every order OrderStore records. A symbol that's hedged across venues
+// (e.g. xfunding's spot long vs. futures short) gets one Position per leg,
+// since the two sides have independent average costs. Quantity is signed
+// (negative means short), so UnrealizedPnL -- not persisted here since it
+// depends on the live price -- is (price - AverageCost) * Quantity
+// regardless of side.
+type Position struct {
+	ID            string       `bson:"_id"`
+	Symbol        string       `bson:"symbol"`
+	Leg           strategy.Leg `bson:"leg,omitempty"`
+	positionState `bson:",inline"`
+}
+
+// PositionStore persists the Position every symbol/leg TradingService has
+// traded has accumulated, rebuilt incrementally as OrderStore records each
+// fill rather than recomputed from order history on every read.
+type PositionStore struct {
+	collection *mongo.Collection
+}
+
+// NewPositionStore builds a PositionStore backed by db's "positions" collection.
+func NewPositionStore(db *mongo.Database) *PositionStore {
+	return &PositionStore{collection: db.Collection("positions")}
+}
+
+// positionKey is the document ID a (symbol, leg) pair is persisted under.
+// leg is omitted for single-venue strategies, which never set it.
+func positionKey(symbol string, leg strategy.Leg) string {
+	if leg == "" {
+		return symbol
+	}
+	return symbol + "/" + string(leg)
+}
+
+// ApplyFill folds one filled order into symbol/leg's running position via
+// position.ApplyFill: a fill that adds to the open side (or opens from
+// flat) rolls quantity and price into the weighted average cost (the same
+// formula dca2 uses for its ladder's average cost); a fill against the open
+// side realizes PnL against the current average cost, flipping long/short
+// if it overshoots what was open -- this is what lets a futures short be
+// closed with a BUY instead of being read as a fresh long. fee is
+// accumulated regardless of side. It returns the position as it stands
+// after the fill.
+func (s *PositionStore) ApplyFill(ctx context.Context, symbol string, leg strategy.Leg, side string, quantity, price, fee decimal.Decimal) (Position, error) {
+	key := positionKey(symbol, leg)
+
+	var pos Position
+	err := s.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&pos)
+	switch {
+	case err == mongo.ErrNoDocuments:
+		pos = Position{ID: key, Symbol: symbol, Leg: leg}
+	case err != nil:
+		return Position{}, fmt.Errorf("failed to load position for %s: %w", key, err)
+	}
+
+	newState, realized, err := position.ApplyFill(position.State{Quantity: pos.Quantity, AverageCost: pos.AverageCost}, side, quantity, price)
+	if err != nil {
+		return Position{}, fmt.Errorf("position %s: %w", key, err)
+	}
+	pos.Quantity = newState.Quantity
+	pos.AverageCost = newState.AverageCost
+	pos.RealizedPnL = pos.RealizedPnL.Add(realized)
+	pos.AccumulatedFees = pos.AccumulatedFees.Add(fee)
+	pos.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.collection.ReplaceOne(ctx, bson.M{"_id": key}, pos, opts); err != nil {
+		return Position{}, fmt.Errorf("failed to persist position for %s: %w", key, err)
+	}
+	return pos, nil
+}
+
+// LoadAll returns every persisted position, for TradingService to log on
+// startup recovery.
+func (s *PositionStore) LoadAll(ctx context.Context) ([]Position, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load positions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var positions []Position
+	if err := cursor.All(ctx, &positions); err != nil {
+		return nil, fmt.Errorf("failed to decode positions: %w", err)
+	}
+	return positions, nil
+}
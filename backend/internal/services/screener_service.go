@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"scope-backend/internal/screener"
+
+	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -28,23 +32,60 @@ type ScreenerResult struct {
 
 type ScreenerService struct {
 	collection *mongo.Collection
+	strategies map[string]screener.ScreenerStrategy
 }
 
-func NewScreenerService(db *mongo.Database) *ScreenerService {
+func NewScreenerService(db *mongo.Database, redisClient *redis.Client) *ScreenerService {
 	return &ScreenerService{
 		collection: db.Collection("screener_results"),
+		strategies: screener.Build(screener.Deps{Mongo: db, Redis: redisClient}),
 	}
 }
 
-func (s *ScreenerService) GetScreenerResults(ctx context.Context, strategy string, limit int64) ([]ScreenerResult, error) {
+// ListStrategies returns every registered strategy's schema so the frontend
+// can auto-render a filter UI per strategy.
+func (s *ScreenerService) ListStrategies() []screener.StrategySchema {
+	schemas := make([]screener.StrategySchema, 0, len(s.strategies))
+	for _, name := range screener.Names() {
+		if strategy, ok := s.strategies[name]; ok {
+			schemas = append(schemas, strategy.Describe())
+		}
+	}
+	return schemas
+}
+
+// GetScreenerResults runs strategyName's Filter (looked up from the screener
+// registry) against the screener_results collection. An empty strategyName
+// returns the latest batch across all strategies. params carries whatever
+// query parameters the strategy's schema declares (e.g. "min_change_percent"
+// for momentum, "filter" for custom).
+func (s *ScreenerService) GetScreenerResults(ctx context.Context, strategyName string, params map[string]string, limit int64) ([]ScreenerResult, error) {
 	filter := bson.M{}
-	if strategy != "" {
-		filter["strategy"] = strategy
+	sortBy := bson.D{{Key: "fetched_at", Value: -1}}
+
+	if strategyName != "" {
+		strategy, ok := s.strategies[strategyName]
+		if !ok {
+			return nil, fmt.Errorf("unknown screener strategy: %s", strategyName)
+		}
+
+		bsonParams := bson.M{}
+		for k, v := range params {
+			bsonParams[k] = v
+		}
+
+		f, sortD, err := strategy.Filter(ctx, bsonParams)
+		if err != nil {
+			return nil, err
+		}
+		filter = f
+		if sortD != nil {
+			sortBy = sortD
+		}
 	}
-	
-	// Sort by fetched_at desc to get latest batch
-	opts := options.Find().SetSort(bson.D{{Key: "fetched_at", Value: -1}}).SetLimit(limit)
-	
+
+	opts := options.Find().SetSort(sortBy).SetLimit(limit)
+
 	cursor, err := s.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
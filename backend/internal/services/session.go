@@ -0,0 +1,17 @@
+package services
+
+import "scope-backend/internal/strategy"
+
+// Session pairs a venue (see strategy.Leg) with the MarketService instance
+// that serves its price data, so strategies that hedge across venues (e.g.
+// xfunding's spot long vs. futures short) can address each leg explicitly
+// instead of assuming a single global MarketService.
+type Session struct {
+	Leg    strategy.Leg
+	Market *MarketService
+}
+
+// NewSession pairs leg with market.
+func NewSession(leg strategy.Leg, market *MarketService) *Session {
+	return &Session{Leg: leg, Market: market}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"scope-backend/internal/feed"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+)
+
+// maxStepPercentageGap is how far a resting quote may drift from a freshly
+// computed target price before cancelIfStale reports it as stale,
+// expressed as a fraction of price (0.0005 == 0.05%).
+const maxStepPercentageGap = 0.0005
+
+// GapStrategyConfig configures one instance of GapStrategy.
+type GapStrategyConfig struct {
+	Symbol          string
+	SourceExchange  string
+	TradingExchange string
+	MinSpread       decimal.Decimal
+	Quantity        decimal.Decimal
+	TickSize        decimal.Decimal // trading market's minimum price increment; zero disables rounding
+	LotSize         decimal.Decimal // trading market's minimum size increment; zero disables rounding
+	UpdateInterval  time.Duration
+	DryRun          bool
+}
+
+// GapStrategy is a simplified version of the classic "gap"/"xgap"
+// cross-exchange market-making strategy: it quotes both sides of the book on
+// TradingExchange using the mid-price observed on SourceExchange (via a live
+// MarketFeed) as its reference.
+//
+// Resting quote prices are persisted in Redis (not kept in memory) so a
+// worker restart still knows what it last quoted when checking for stale
+// quotes. Run places a fresh bid and ask on every tick, whether or not the
+// previous level changed.
+type GapStrategy struct {
+	cfg            GapStrategyConfig
+	tradingService *TradingService
+	redisClient    *redis.Client
+
+	mu        sync.Mutex
+	sourceBid decimal.Decimal
+	sourceAsk decimal.Decimal
+	haveQuote bool
+}
+
+// NewGapStrategy builds a GapStrategy and subscribes it to sourceFeed's book
+// ticker for cfg.Symbol.
+func NewGapStrategy(cfg GapStrategyConfig, sourceFeed feed.MarketFeed, tradingService *TradingService, redisClient *redis.Client) (*GapStrategy, error) {
+	g := &GapStrategy{
+		cfg:            cfg,
+		tradingService: tradingService,
+		redisClient:    redisClient,
+	}
+
+	if err := sourceFeed.SubscribeBookTicker([]string{cfg.Symbol}, g.onSourceBookTicker); err != nil {
+		return nil, fmt.Errorf("gap strategy: failed to subscribe to %s book ticker on %s: %w", cfg.Symbol, cfg.SourceExchange, err)
+	}
+	return g, nil
+}
+
+func (g *GapStrategy) onSourceBookTicker(bt feed.BookTicker) {
+	if !strings.EqualFold(bt.Symbol, g.cfg.Symbol) {
+		return
+	}
+	g.mu.Lock()
+	g.sourceBid = bt.BidPrice
+	g.sourceAsk = bt.AskPrice
+	g.haveQuote = true
+	g.mu.Unlock()
+}
+
+// Run executes one tick of the strategy: read the source mid-price, compute
+// target quotes, cancel any resting quote that drifted too far, and place
+// fresh bid/ask orders on the trading exchange (unless DryRun).
+func (g *GapStrategy) Run(ctx context.Context) error {
+	g.mu.Lock()
+	sourceBid, sourceAsk, haveQuote := g.sourceBid, g.sourceAsk, g.haveQuote
+	g.mu.Unlock()
+
+	if !haveQuote {
+		log.Printf("[gap-strategy] %s: no quote yet from %s, skipping tick", g.cfg.Symbol, g.cfg.SourceExchange)
+		return nil
+	}
+
+	mid := sourceBid.Add(sourceAsk).Div(decimal.NewFromInt(2))
+	halfSpread := g.cfg.MinSpread.Div(decimal.NewFromInt(2))
+	targetBid := roundToStep(mid.Sub(halfSpread), g.cfg.TickSize)
+	targetAsk := roundToStep(mid.Add(halfSpread), g.cfg.TickSize)
+	quantity := roundToStep(g.cfg.Quantity, g.cfg.LotSize)
+
+	g.cancelIfStale(ctx, "bid", targetBid)
+	g.cancelIfStale(ctx, "ask", targetAsk)
+
+	log.Printf("[gap-strategy] %s: source=%s mid=%s trading=%s bid=%s ask=%s qty=%s dry_run=%t",
+		g.cfg.Symbol, g.cfg.SourceExchange, mid, g.cfg.TradingExchange, targetBid, targetAsk, quantity, g.cfg.DryRun)
+
+	if g.cfg.DryRun {
+		log.Printf("[gap-strategy] %s: dry run, skipping order placement", g.cfg.Symbol)
+		return nil
+	}
+
+	if err := g.tradingService.PlaceLimitOrder(ctx, g.cfg.TradingExchange, g.cfg.Symbol, "BUY", quantity, targetBid); err != nil {
+		return fmt.Errorf("gap strategy: failed to place bid: %w", err)
+	}
+	if err := g.tradingService.PlaceLimitOrder(ctx, g.cfg.TradingExchange, g.cfg.Symbol, "SELL", quantity, targetAsk); err != nil {
+		return fmt.Errorf("gap strategy: failed to place ask: %w", err)
+	}
+
+	g.storeQuote(ctx, "bid", targetBid)
+	g.storeQuote(ctx, "ask", targetAsk)
+	return nil
+}
+
+// cancelIfStale logs a cancellation when the previously stored quote for
+// side has drifted more than maxStepPercentageGap from target. There is no
+// real resting order to cancel in this simplified execution model, so this
+// is the hook a real exchange integration would replace with a CancelOrder call.
+func (g *GapStrategy) cancelIfStale(ctx context.Context, side string, target decimal.Decimal) {
+	prevStr, err := g.redisClient.Get(ctx, g.quoteKey(side)).Result()
+	if err != nil {
+		return // nothing resting yet
+	}
+
+	prev, err := decimal.NewFromString(prevStr)
+	if err != nil || prev.IsZero() {
+		return
+	}
+
+	drift, _ := target.Sub(prev).Div(prev).Abs().Float64()
+	if drift <= maxStepPercentageGap {
+		return
+	}
+
+	log.Printf("[gap-strategy] %s: canceling stale %s quote at %s (drifted %.4f%% from target %s)",
+		g.cfg.Symbol, side, prev, drift*100, target)
+}
+
+func (g *GapStrategy) storeQuote(ctx context.Context, side string, price decimal.Decimal) {
+	if err := g.redisClient.Set(ctx, g.quoteKey(side), price.String(), 0).Err(); err != nil {
+		log.Printf("[gap-strategy] %s: failed to persist %s quote: %v", g.cfg.Symbol, side, err)
+	}
+}
+
+func (g *GapStrategy) quoteKey(side string) string {
+	return fmt.Sprintf("gap:quote:%s:%s:%s", g.cfg.TradingExchange, g.cfg.Symbol, side)
+}
+
+// roundToStep rounds value down to the nearest multiple of step. A zero step
+// means the market has no tick/lot size configured, so value is returned unchanged.
+func roundToStep(value, step decimal.Decimal) decimal.Decimal {
+	if step.IsZero() {
+		return value
+	}
+	return value.Div(step).Floor().Mul(step)
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// strategyStateDoc is the envelope every persisted instance's state is
+// wrapped in; State holds whatever struct the strategy itself passed to Save.
+type strategyStateDoc struct {
+	ID    string   `bson:"_id"`
+	State bson.Raw `bson:"state"`
+}
+
+// StrategyStateStore persists arbitrary per-instance strategy state (e.g.
+// dca2's open ladder) so a restart can recover it, keyed by an opaque
+// instance key chosen by the caller (TradingService uses "id/symbol").
+type StrategyStateStore struct {
+	collection *mongo.Collection
+}
+
+// NewStrategyStateStore builds a StrategyStateStore backed by db's
+// "strategy_state" collection.
+func NewStrategyStateStore(db *mongo.Database) *StrategyStateStore {
+	return &StrategyStateStore{collection: db.Collection("strategy_state")}
+}
+
+// Save upserts state under key. state must be bson-marshalable.
+func (s *StrategyStateStore) Save(ctx context.Context, key string, state any) error {
+	raw, err := bson.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal strategy state for %s: %w", key, err)
+	}
+
+	doc := strategyStateDoc{ID: key, State: raw}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.collection.ReplaceOne(ctx, bson.M{"_id": key}, doc, opts); err != nil {
+		return fmt.Errorf("failed to persist strategy state for %s: %w", key, err)
+	}
+	return nil
+}
+
+// Load decodes the state persisted under key into out, which must be a
+// pointer of the same type previously passed to Save. ok is false if
+// nothing has been persisted under key yet.
+func (s *StrategyStateStore) Load(ctx context.Context, key string, out any) (ok bool, err error) {
+	var doc strategyStateDoc
+	err = s.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&doc)
+	switch {
+	case err == mongo.ErrNoDocuments:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to load strategy state for %s: %w", key, err)
+	}
+
+	if err := bson.Unmarshal(doc.State, out); err != nil {
+		return false, fmt.Errorf("failed to unmarshal strategy state for %s: %w", key, err)
+	}
+	return true, nil
+}
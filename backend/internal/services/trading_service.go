@@ -4,68 +4,255 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strconv"
-	"strings"
 	"time"
 
+	"scope-backend/internal/strategy"
+
 	"github.com/shopspring/decimal"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type TradeOrder struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Symbol    string             `bson:"symbol" json:"symbol"`
-	Side      string             `bson:"side" json:"side"` // BUY or SELL
-	Quantity  int                `bson:"quantity" json:"quantity"`
-	Price     decimal.Decimal    `bson:"price" json:"price"`
-	Status    string             `bson:"status" json:"status"` // FILLED, FAILED
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Symbol   string             `bson:"symbol" json:"symbol"`
+	Side     string             `bson:"side" json:"side"` // BUY or SELL
+	Quantity decimal.Decimal    `bson:"quantity" json:"quantity"`
+	Price    decimal.Decimal    `bson:"price" json:"price"`
+	Status   string             `bson:"status" json:"status"` // FILLED, FAILED
+	// Leg is which venue this order traded on, for strategies that hedge
+	// across venues (e.g. xfunding's spot long vs. futures short) so their
+	// PnL can be reconstructed leg by leg. Empty for single-venue strategies.
+	Leg       strategy.Leg `bson:"leg,omitempty" json:"leg,omitempty"`
+	CreatedAt time.Time    `bson:"created_at" json:"created_at"`
 }
 
+// takerFeeRate is the assumed fee rate charged on a filled order, used to
+// debit FeeBudget after ExecuteOrder fills. It's a flat estimate rather than
+// an exchange-quoted fee, since ExecuteOrder itself only simulates fills.
+var takerFeeRate = decimal.NewFromFloat(0.001)
+
 type TradingService struct {
 	screenerService *ScreenerService
 	marketService   *MarketService
+	feeBudget       *FeeBudget // nil disables fee/volume budget enforcement
+
+	orderStore    *OrderStore    // nil disables persisting order history (Mongo not configured)
+	positionStore *PositionStore // nil disables persisting/deriving positions (Mongo not configured)
+
+	futuresSession     *Session            // nil disables strategies that hedge onto a futures leg (e.g. xfunding)
+	fundingRateService *FundingRateService // nil disables strategies that trade on funding rate (e.g. xfunding)
+
+	indicators   *IndicatorService // always set; reads off marketService
+	activeOrders *ActiveOrderBook  // always set; in-memory, no external dependency to be nil-able for
+
+	strategies []strategy.Strategy // built from config.Config.Strategies; see package strategy
 }
 
-func NewTradingService(screener *ScreenerService, market *MarketService) *TradingService {
-	return &TradingService{
-		screenerService: screener,
-		marketService:   market,
+// NewTradingService builds a TradingService and, for each instanceCfg,
+// instantiates and initializes the registered strategy it names (see
+// package strategy). An unknown strategy ID is a configuration error:
+// NewTradingService fails fast rather than silently skipping it. stateStore,
+// orderStore, positionStore, futuresSession, and fundingRateService may all
+// be nil, in which case strategies that need them (dca2's restart recovery;
+// xfunding's futures leg and funding-rate polling) run degraded as
+// documented on each, and order/position history simply isn't persisted.
+// If orderStore or positionStore are set, NewTradingService logs what's
+// already on the books before any strategy runs its first tick (see
+// recoverOrdersAndPositions).
+func NewTradingService(screener *ScreenerService, market *MarketService, feeBudget *FeeBudget, orderStore *OrderStore, positionStore *PositionStore, stateStore *StrategyStateStore, futuresSession *Session, fundingRateService *FundingRateService, instanceConfigs []strategy.InstanceConfig) (*TradingService, error) {
+	s := &TradingService{
+		screenerService:    screener,
+		marketService:      market,
+		feeBudget:          feeBudget,
+		orderStore:         orderStore,
+		positionStore:      positionStore,
+		futuresSession:     futuresSession,
+		fundingRateService: fundingRateService,
+		indicators:         NewIndicatorService(market),
+		activeOrders:       NewActiveOrderBook(),
 	}
-}
 
-func (s *TradingService) RunAutomatedStrategy(ctx context.Context) error {
-	log.Println("Running Automated Trading Strategy based on Screener Signals...")
+	if err := s.recoverOrdersAndPositions(context.Background()); err != nil {
+		return nil, err
+	}
 
-	// 1. Get Top Gainers (Simulated strategy filter)
-	results, err := s.screenerService.GetScreenerResults(ctx, "", 20)
-	if err != nil {
-		return fmt.Errorf("failed to get screener results: %w", err)
+	for _, cfg := range instanceConfigs {
+		st, ok := strategy.New(cfg.ID)
+		if !ok {
+			return nil, fmt.Errorf("unknown strategy id %q for symbol %s (known: %v)", cfg.ID, cfg.Symbol, strategy.IDs())
+		}
+
+		stateKey := cfg.ID + "/" + cfg.Symbol
+		deps := strategy.Deps{
+			ExecuteOrder:           s.ExecuteOrder,
+			ExecuteFractionalOrder: s.ExecuteFractionalOrder,
+			GetPrice:               s.strategyGetPrice,
+			ScreenerCandidates:     s.strategyScreenerCandidates,
+			SaveState:              func(ctx context.Context, state any) error { return strategySaveState(ctx, stateStore, stateKey, state) },
+			LoadState: func(ctx context.Context, out any) (bool, error) {
+				return strategyLoadState(ctx, stateStore, stateKey, out)
+			},
+			GetFundingRate:       s.strategyGetFundingRate,
+			OpenFuturesPosition:  s.OpenFuturesPosition,
+			CloseFuturesPosition: s.CloseFuturesPosition,
+			TransferCollateral:   s.TransferCollateral,
+			GetSMA: func(symbol string, window int) (decimal.Decimal, error) {
+				return s.indicators.SMA(symbol, cfg.Interval, window)
+			},
+			GetStdDev: func(symbol string, window int) (decimal.Decimal, error) {
+				return s.indicators.StdDev(symbol, cfg.Interval, window)
+			},
+			GetBestBidAsk: s.strategyGetBestBidAsk,
+			PlaceMakerOrder: func(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) (string, error) {
+				return s.PlaceMakerOrder(ctx, stateKey, symbol, side, quantity, price)
+			},
+			CancelAllMakerOrders: func(ctx context.Context) ([]string, error) { return s.CancelAllMakerOrders(ctx, stateKey) },
+			CancelOrder:          func(ctx context.Context, orderID string) error { return s.CancelOrder(ctx, stateKey, orderID) },
+			Params:               cfg,
+			OnReady:              func() { log.Printf("[strategy] %s/%s ready", cfg.ID, cfg.Symbol) },
+			OnClosed:             func() { log.Printf("[strategy] %s/%s closed", cfg.ID, cfg.Symbol) },
+			OnError:              func(err error) { log.Printf("[strategy] %s/%s error: %v", cfg.ID, cfg.Symbol, err) },
+		}
+		if err := st.Init(context.Background(), deps); err != nil {
+			return nil, fmt.Errorf("failed to init strategy %s/%s: %w", cfg.ID, cfg.Symbol, err)
+		}
+		s.strategies = append(s.strategies, st)
 	}
 
-	for _, stock := range results {
-		// Parse Change %
-		changeStr := strings.TrimSuffix(stock.Change, "%")
-		change, err := strconv.ParseFloat(changeStr, 64)
+	return s, nil
+}
+
+// recoverOrdersAndPositions logs what orderStore and positionStore already
+// have on disk at startup, mirroring the per-instance recovery log dca2
+// does under RecoverWhenStart. There's nothing to rebuild into memory here
+// -- every fill method reads/writes straight through to Mongo -- but a
+// restart should make clear what's already on the books before strategies
+// place their first order.
+func (s *TradingService) recoverOrdersAndPositions(ctx context.Context) error {
+	if s.orderStore != nil {
+		count, err := s.orderStore.Count(ctx)
 		if err != nil {
-			continue // Skip if parse error
+			return fmt.Errorf("failed to recover order history: %w", err)
 		}
+		log.Printf("[trading] recovered %d persisted order(s)", count)
+	}
 
-		// STRATEGY: Momentum Buy
-		// If stock is up > 3% today, Buy 10 shares
-		if change > 3.0 {
-			// Get current real-time price
-			priceData, err := s.marketService.GetPrice(stock.Ticker)
-			if err != nil {
-				log.Printf("Failed to get price for %s: %v", stock.Ticker, err)
-				continue
-			}
+	if s.positionStore != nil {
+		positions, err := s.positionStore.LoadAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to recover positions: %w", err)
+		}
+		for _, p := range positions {
+			log.Printf("[trading] recovered position %s: qty=%s avg_cost=%s realized_pnl=%s fees=%s",
+				p.ID, p.Quantity.String(), p.AverageCost.String(), p.RealizedPnL.String(), p.AccumulatedFees.String())
+		}
+	}
+	return nil
+}
 
-			// Execute Order
-			err = s.ExecuteOrder(ctx, stock.Ticker, "BUY", 10, priceData.Price)
-			if err != nil {
-				log.Printf("Order execution failed: %v", err)
-			}
+// recordFill persists order, if orderStore is configured, and folds it into
+// symbol/leg's running position, if positionStore is configured. Errors are
+// logged rather than returned: a failure to persist history shouldn't
+// unwind an already-simulated fill, matching how RecordFill's fee-budget
+// errors are handled below.
+func (s *TradingService) recordFill(ctx context.Context, order TradeOrder, fee decimal.Decimal) {
+	if s.orderStore != nil {
+		if err := s.orderStore.Record(ctx, order); err != nil {
+			log.Printf("Failed to persist order: %v", err)
+		}
+	}
+	if s.positionStore != nil {
+		if _, err := s.positionStore.ApplyFill(ctx, order.Symbol, order.Leg, order.Side, order.Quantity, order.Price, fee); err != nil {
+			log.Printf("Failed to update position for %s: %v", order.Symbol, err)
+		}
+	}
+}
+
+// strategyGetFundingRate adapts FundingRateService.GetFundingRate to the
+// plain func(string) (decimal.Decimal, error) shape strategy.Deps needs,
+// erroring if fundingRateService is nil (no funding-rate source configured).
+func (s *TradingService) strategyGetFundingRate(symbol string) (decimal.Decimal, error) {
+	if s.fundingRateService == nil {
+		return decimal.Decimal{}, fmt.Errorf("funding rate source not configured")
+	}
+	return s.fundingRateService.GetFundingRate(symbol)
+}
+
+// strategySaveState adapts StrategyStateStore.Save to strategy.Deps.SaveState,
+// no-op if stateStore is nil (Mongo not configured).
+func strategySaveState(ctx context.Context, stateStore *StrategyStateStore, key string, state any) error {
+	if stateStore == nil {
+		return nil
+	}
+	return stateStore.Save(ctx, key, state)
+}
+
+// strategyLoadState adapts StrategyStateStore.Load to strategy.Deps.LoadState,
+// reporting ok=false if stateStore is nil (Mongo not configured).
+func strategyLoadState(ctx context.Context, stateStore *StrategyStateStore, key string, out any) (bool, error) {
+	if stateStore == nil {
+		return false, nil
+	}
+	return stateStore.Load(ctx, key, out)
+}
+
+// strategyGetPrice adapts MarketService.GetPrice to the plain
+// func(string) (decimal.Decimal, error) shape strategy.Deps needs.
+func (s *TradingService) strategyGetPrice(symbol string) (decimal.Decimal, error) {
+	price, err := s.marketService.GetPrice(symbol)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return price.Price, nil
+}
+
+// strategyGetBestBidAsk adapts MarketService.GetBestBidAsk to the plain
+// func(string) (*decimal.Decimal, *decimal.Decimal, error) shape
+// strategy.Deps needs (so package strategy doesn't have to import
+// services.Order).
+func (s *TradingService) strategyGetBestBidAsk(symbol string) (bid, ask *decimal.Decimal, err error) {
+	b, a, err := s.marketService.GetBestBidAsk(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b != nil {
+		bid = &b.Price
+	}
+	if a != nil {
+		ask = &a.Price
+	}
+	return bid, ask, nil
+}
+
+// strategyScreenerCandidates adapts ScreenerService.GetScreenerResults to
+// the []strategy.ScreenerCandidate shape strategy.Deps needs.
+func (s *TradingService) strategyScreenerCandidates(ctx context.Context, screenerStrategy string, limit int64) ([]strategy.ScreenerCandidate, error) {
+	results, err := s.screenerService.GetScreenerResults(ctx, screenerStrategy, nil, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]strategy.ScreenerCandidate, len(results))
+	for i, r := range results {
+		candidates[i] = strategy.ScreenerCandidate{Ticker: r.Ticker, Change: r.Change}
+	}
+	return candidates, nil
+}
+
+// RunAutomatedStrategy runs one tick of every configured strategy instance
+// (see package strategy). It used to contain the momentum-buy logic
+// directly; that logic now lives in the built-in "momentum" strategy so
+// other strategies (grid, bollinger, flashcrash, DCA) can be added or
+// removed via config.Config.Strategies without touching this method.
+func (s *TradingService) RunAutomatedStrategy(ctx context.Context) error {
+	if len(s.strategies) == 0 {
+		log.Println("No automated trading strategies configured, skipping tick")
+		return nil
+	}
+
+	for _, st := range s.strategies {
+		if err := st.Run(ctx); err != nil {
+			log.Printf("[strategy] %s tick failed: %v", st.ID(), err)
 		}
 	}
 	return nil
@@ -77,13 +264,25 @@ func (s *TradingService) ExecuteOrder(ctx context.Context, symbol, side string,
 	// 2. Create Order record in DB
 	// 3. Update Portfolio
 
+	notional := price.Mul(decimal.NewFromInt(int64(quantity)))
+	if s.feeBudget != nil {
+		ok, err := s.feeBudget.CheckAndReserve(ctx, symbol, notional)
+		if err != nil {
+			return fmt.Errorf("failed to check fee budget: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("daily fee/volume budget exhausted for %s", symbol)
+		}
+	}
+
 	order := TradeOrder{
 		ID:        primitive.NewObjectID(),
 		Symbol:    symbol,
 		Side:      side,
-		Quantity:  quantity,
+		Quantity:  decimal.NewFromInt(int64(quantity)),
 		Price:     price,
 		Status:    "FILLED",
+		Leg:       strategy.LegSpot,
 		CreatedAt: time.Now(),
 	}
 
@@ -91,5 +290,225 @@ func (s *TradingService) ExecuteOrder(ctx context.Context, symbol, side string,
 	log.Printf("[ORDER EXECUTION] %s: %s %d shares of %s at $%s | Status: %s",
 		time.Now().Format(time.RFC3339), side, quantity, symbol, price.String(), order.Status)
 
+	fee := notional.Mul(takerFeeRate)
+	if s.feeBudget != nil {
+		if err := s.feeBudget.RecordFill(ctx, symbol, fee, notional); err != nil {
+			log.Printf("Failed to record fill against fee budget: %v", err)
+		}
+	}
+	s.recordFill(ctx, order, fee)
+
+	return nil
+}
+
+// ExecuteFractionalOrder is ExecuteOrder for strategies that size positions
+// in fractional quantities (e.g. dca2's quote-currency budgeting) rather
+// than whole shares.
+func (s *TradingService) ExecuteFractionalOrder(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) error {
+	notional := price.Mul(quantity)
+	if s.feeBudget != nil {
+		ok, err := s.feeBudget.CheckAndReserve(ctx, symbol, notional)
+		if err != nil {
+			return fmt.Errorf("failed to check fee budget: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("daily fee/volume budget exhausted for %s", symbol)
+		}
+	}
+
+	order := TradeOrder{
+		ID:        primitive.NewObjectID(),
+		Symbol:    symbol,
+		Side:      side,
+		Quantity:  quantity,
+		Price:     price,
+		Status:    "FILLED",
+		Leg:       strategy.LegSpot,
+		CreatedAt: time.Now(),
+	}
+
+	log.Printf("[ORDER EXECUTION] %s: %s %s %s at $%s | Status: %s",
+		time.Now().Format(time.RFC3339), side, quantity.String(), symbol, price.String(), order.Status)
+
+	fee := notional.Mul(takerFeeRate)
+	if s.feeBudget != nil {
+		if err := s.feeBudget.RecordFill(ctx, symbol, fee, notional); err != nil {
+			log.Printf("Failed to record fill against fee budget: %v", err)
+		}
+	}
+	s.recordFill(ctx, order, fee)
+
+	return nil
+}
+
+// OpenFuturesPosition opens (or adds to) a leveraged futures position on
+// symbol, recording a TradeOrder tagged LegFutures. Like ExecuteOrder, it's
+// a simulated fill: there is no margin engine or resting order behind it.
+func (s *TradingService) OpenFuturesPosition(ctx context.Context, symbol, side string, quantity, price, leverage decimal.Decimal) error {
+	if s.futuresSession == nil {
+		return fmt.Errorf("futures venue not configured")
+	}
+
+	notional := price.Mul(quantity)
+	if s.feeBudget != nil {
+		ok, err := s.feeBudget.CheckAndReserve(ctx, symbol, notional)
+		if err != nil {
+			return fmt.Errorf("failed to check fee budget: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("daily fee/volume budget exhausted for %s", symbol)
+		}
+	}
+
+	order := TradeOrder{
+		ID:        primitive.NewObjectID(),
+		Symbol:    symbol,
+		Side:      side,
+		Quantity:  quantity,
+		Price:     price,
+		Status:    "FILLED",
+		Leg:       strategy.LegFutures,
+		CreatedAt: time.Now(),
+	}
+
+	log.Printf("[FUTURES OPEN] %s: %s %s %s at $%s (%sx leverage) | Status: %s",
+		time.Now().Format(time.RFC3339), side, quantity.String(), symbol, price.String(), leverage.String(), order.Status)
+
+	fee := notional.Mul(takerFeeRate)
+	if s.feeBudget != nil {
+		if err := s.feeBudget.RecordFill(ctx, symbol, fee, notional); err != nil {
+			log.Printf("Failed to record fill against fee budget: %v", err)
+		}
+	}
+	s.recordFill(ctx, order, fee)
+
+	return nil
+}
+
+// CloseFuturesPosition closes (or reduces) a futures position on symbol,
+// recording a TradeOrder tagged LegFutures.
+func (s *TradingService) CloseFuturesPosition(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) error {
+	if s.futuresSession == nil {
+		return fmt.Errorf("futures venue not configured")
+	}
+
+	notional := price.Mul(quantity)
+	if s.feeBudget != nil {
+		ok, err := s.feeBudget.CheckAndReserve(ctx, symbol, notional)
+		if err != nil {
+			return fmt.Errorf("failed to check fee budget: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("daily fee/volume budget exhausted for %s", symbol)
+		}
+	}
+
+	order := TradeOrder{
+		ID:        primitive.NewObjectID(),
+		Symbol:    symbol,
+		Side:      side,
+		Quantity:  quantity,
+		Price:     price,
+		Status:    "FILLED",
+		Leg:       strategy.LegFutures,
+		CreatedAt: time.Now(),
+	}
+
+	log.Printf("[FUTURES CLOSE] %s: %s %s %s at $%s | Status: %s",
+		time.Now().Format(time.RFC3339), side, quantity.String(), symbol, price.String(), order.Status)
+
+	fee := notional.Mul(takerFeeRate)
+	if s.feeBudget != nil {
+		if err := s.feeBudget.RecordFill(ctx, symbol, fee, notional); err != nil {
+			log.Printf("Failed to record fill against fee budget: %v", err)
+		}
+	}
+	s.recordFill(ctx, order, fee)
+
+	return nil
+}
+
+// TransferCollateral moves amount of symbol's quote currency between venue
+// wallets, e.g. funding a futures short's margin from the spot wallet that
+// just bought the hedge's long leg. There is no real wallet ledger behind
+// this yet, so it's simulated the same way ExecuteOrder simulates fills.
+func (s *TradingService) TransferCollateral(ctx context.Context, symbol string, from, to strategy.Leg, amount decimal.Decimal) error {
+	if s.futuresSession == nil {
+		return fmt.Errorf("futures venue not configured")
+	}
+
+	log.Printf("[COLLATERAL TRANSFER] %s: moved %s %s collateral from %s to %s",
+		time.Now().Format(time.RFC3339), amount.String(), symbol, from, to)
+	return nil
+}
+
+// PlaceMakerOrder quotes a resting limit order on symbol for an automated
+// strategy instance (keyed by instanceKey), recording it in activeOrders so
+// a later CancelAllMakerOrders call can find it. Like PlaceLimitOrder,
+// there is no real resting-order engine behind it yet.
+func (s *TradingService) PlaceMakerOrder(ctx context.Context, instanceKey, symbol, side string, quantity, price decimal.Decimal) (string, error) {
+	notional := price.Mul(quantity)
+	if s.feeBudget != nil {
+		ok, err := s.feeBudget.CheckAndReserve(ctx, symbol, notional)
+		if err != nil {
+			return "", fmt.Errorf("failed to check fee budget: %w", err)
+		}
+		if !ok {
+			return "", fmt.Errorf("daily fee/volume budget exhausted for %s", symbol)
+		}
+	}
+
+	order := &ActiveOrder{ID: primitive.NewObjectID().Hex(), Symbol: symbol, Side: side, Quantity: quantity, Price: price}
+	s.activeOrders.Place(instanceKey, order)
+
+	log.Printf("[MAKER ORDER] %s: %s %s %s at $%s (id %s)",
+		time.Now().Format(time.RFC3339), side, quantity.String(), symbol, price.String(), order.ID)
+
+	return order.ID, nil
+}
+
+// CancelOrder cancels the maker order orderID currently resting for
+// instanceKey. It is a no-op (not an error) if orderID isn't tracked,
+// matching cancel semantics on a real exchange where a stale/already-filled
+// order ID is harmless to cancel again.
+func (s *TradingService) CancelOrder(ctx context.Context, instanceKey, orderID string) error {
+	if order := s.activeOrders.Cancel(instanceKey, orderID); order != nil {
+		log.Printf("[MAKER ORDER] %s: canceled %s %s %s at $%s (id %s)",
+			time.Now().Format(time.RFC3339), order.Side, order.Quantity.String(), order.Symbol, order.Price.String(), order.ID)
+	}
+	return nil
+}
+
+// CancelAllMakerOrders cancels every maker order currently resting for
+// instanceKey, returning their IDs.
+func (s *TradingService) CancelAllMakerOrders(ctx context.Context, instanceKey string) ([]string, error) {
+	orders := s.activeOrders.CancelAll(instanceKey)
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+		log.Printf("[MAKER ORDER] %s: canceled %s %s %s at $%s (id %s)",
+			time.Now().Format(time.RFC3339), order.Side, order.Quantity.String(), order.Symbol, order.Price.String(), order.ID)
+	}
+	return ids, nil
+}
+
+// PlaceLimitOrder quotes a resting limit order on exchange for symbol, for
+// market-making strategies (e.g. GapStrategy) that work in fractional
+// quantities rather than ExecuteOrder's integer-share market fills.
+func (s *TradingService) PlaceLimitOrder(ctx context.Context, exchange, symbol, side string, quantity, price decimal.Decimal) error {
+	notional := price.Mul(quantity)
+	if s.feeBudget != nil {
+		ok, err := s.feeBudget.CheckAndReserve(ctx, symbol, notional)
+		if err != nil {
+			return fmt.Errorf("failed to check fee budget: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("daily fee/volume budget exhausted for %s", symbol)
+		}
+	}
+
+	// For now, just log it as "Connected to Execution Service"
+	log.Printf("[LIMIT ORDER] %s: %s %s %s at $%s on %s",
+		time.Now().Format(time.RFC3339), side, quantity.String(), symbol, price.String(), exchange)
 	return nil
 }
@@ -0,0 +1,130 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"scope-backend/internal/feed"
+
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	Register("bollinger_pp", func() Strategy { return &bollingerPPStrategy{} })
+}
+
+// bollingerPPStrategy is a Bollinger-band mean-reversion market maker: it
+// quotes a BUY and a SELL around the best bid/ask, clamped to bands
+// sma +/- BandWidth*stddev computed over Window klines at Interval, and
+// cancels and reprices both quotes on every tick. A neutral inner band
+// (sma +/- NeutralWidth*stddev) suppresses quoting whichever side would
+// land inside it, to avoid repeatedly trading through a flat, low-volatility
+// regime.
+type bollingerPPStrategy struct {
+	BaseStrategy
+	deps Deps
+}
+
+func (s *bollingerPPStrategy) ID() string { return "bollinger_pp" }
+
+func (s *bollingerPPStrategy) Init(ctx context.Context, deps Deps) error {
+	if deps.Params.Symbol == "" {
+		return fmt.Errorf("bollinger_pp strategy: symbol is required")
+	}
+	if deps.Params.Window <= 0 {
+		return fmt.Errorf("bollinger_pp strategy: window is required")
+	}
+	if deps.Params.BandWidth.IsZero() {
+		return fmt.Errorf("bollinger_pp strategy: band_width is required")
+	}
+	if deps.Params.Quantity.IsZero() {
+		return fmt.Errorf("bollinger_pp strategy: quantity is required")
+	}
+	s.deps = deps
+
+	if s.deps.OnReady != nil {
+		s.deps.OnReady()
+	}
+	return nil
+}
+
+func (s *bollingerPPStrategy) Run(ctx context.Context) error {
+	return s.reprice(ctx)
+}
+
+// OnKline reprices on every new kline for this instance's symbol, matching
+// the strategy's "cancel and reprice on each new kline" design; whichever
+// orchestration drives it (today's timer-based Run, or a future live-feed
+// dispatch to OnKline) hits the same logic.
+func (s *bollingerPPStrategy) OnKline(kline feed.Kline) {
+	if kline.Symbol != s.deps.Params.Symbol {
+		return
+	}
+	if err := s.reprice(context.Background()); err != nil {
+		log.Printf("[bollinger_pp-strategy] %s: reprice on kline failed: %v", s.deps.Params.Symbol, err)
+	}
+}
+
+// reprice computes the current Bollinger bands, cancels this instance's
+// existing quotes, and places a fresh BUY and/or SELL, skipping whichever
+// side's price would land inside the neutral inner band.
+func (s *bollingerPPStrategy) reprice(ctx context.Context) error {
+	symbol := s.deps.Params.Symbol
+	window := s.deps.Params.Window
+
+	sma, err := s.deps.GetSMA(symbol, window)
+	if err != nil {
+		err = fmt.Errorf("bollinger_pp strategy: failed to compute SMA: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+	stdDev, err := s.deps.GetStdDev(symbol, window)
+	if err != nil {
+		err = fmt.Errorf("bollinger_pp strategy: failed to compute stddev: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+
+	upper := sma.Add(s.deps.Params.BandWidth.Mul(stdDev))
+	lower := sma.Sub(s.deps.Params.BandWidth.Mul(stdDev))
+	neutralUpper := sma.Add(s.deps.Params.NeutralWidth.Mul(stdDev))
+	neutralLower := sma.Sub(s.deps.Params.NeutralWidth.Mul(stdDev))
+
+	bid, ask, err := s.deps.GetBestBidAsk(symbol)
+	if err != nil {
+		err = fmt.Errorf("bollinger_pp strategy: failed to get order book: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+
+	if _, err := s.deps.CancelAllMakerOrders(ctx); err != nil {
+		log.Printf("[bollinger_pp-strategy] %s: failed to cancel existing quotes: %v", symbol, err)
+	}
+
+	if bid != nil {
+		buyPrice := decimal.Max(bid.Sub(s.deps.Params.Spread), lower)
+		if buyPrice.LessThan(neutralLower) || buyPrice.GreaterThan(neutralUpper) {
+			if _, err := s.deps.PlaceMakerOrder(ctx, symbol, "BUY", s.deps.Params.Quantity, buyPrice); err != nil {
+				log.Printf("[bollinger_pp-strategy] %s: failed to place BUY quote: %v", symbol, err)
+			}
+		}
+	}
+
+	if ask != nil {
+		sellPrice := decimal.Min(ask.Add(s.deps.Params.Spread), upper)
+		if sellPrice.LessThan(neutralLower) || sellPrice.GreaterThan(neutralUpper) {
+			if _, err := s.deps.PlaceMakerOrder(ctx, symbol, "SELL", s.deps.Params.Quantity, sellPrice); err != nil {
+				log.Printf("[bollinger_pp-strategy] %s: failed to place SELL quote: %v", symbol, err)
+			}
+		}
+	}
+
+	return nil
+}
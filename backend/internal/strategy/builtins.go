@@ -0,0 +1,93 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// defaultPriceDeviation is the change-percent floor momentum buys above when
+// an instance doesn't set PriceDeviation, matching the original hard-coded
+// TradingService.RunAutomatedStrategy threshold.
+var defaultPriceDeviation = decimal.NewFromInt(3)
+
+// defaultQuantity is the share count momentum buys when an instance doesn't
+// set Quantity, matching the original hard-coded TradingService.RunAutomatedStrategy buy size.
+var defaultQuantity = decimal.NewFromInt(10)
+
+func init() {
+	Register("momentum", func() Strategy { return &momentumStrategy{} })
+}
+
+// momentumStrategy buys Params.Quantity shares of Params.Symbol whenever the
+// screener's momentum scan reports it up more than Params.PriceDeviation
+// percent for the day. It is the built-in port of the original
+// TradingService.RunAutomatedStrategy momentum-buy logic, scoped to one
+// symbol per instance instead of scanning the whole top-20 list.
+type momentumStrategy struct {
+	BaseStrategy
+	deps Deps
+}
+
+func (s *momentumStrategy) ID() string { return "momentum" }
+
+func (s *momentumStrategy) Init(ctx context.Context, deps Deps) error {
+	if deps.Params.Symbol == "" {
+		return fmt.Errorf("momentum strategy: symbol is required")
+	}
+	s.deps = deps
+	if s.deps.OnReady != nil {
+		s.deps.OnReady()
+	}
+	return nil
+}
+
+func (s *momentumStrategy) Run(ctx context.Context) error {
+	threshold := s.deps.Params.PriceDeviation
+	if threshold.IsZero() {
+		threshold = defaultPriceDeviation
+	}
+
+	candidates, err := s.deps.ScreenerCandidates(ctx, "momentum", 20)
+	if err != nil {
+		err = fmt.Errorf("momentum strategy: failed to get screener candidates: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+
+	for _, c := range candidates {
+		if !strings.EqualFold(c.Ticker, s.deps.Params.Symbol) {
+			continue
+		}
+
+		change, err := strconv.ParseFloat(strings.TrimSuffix(c.Change, "%"), 64)
+		if err != nil {
+			continue // skip if parse error
+		}
+		if !decimal.NewFromFloat(change).GreaterThan(threshold) {
+			continue
+		}
+
+		price, err := s.deps.GetPrice(c.Ticker)
+		if err != nil {
+			log.Printf("[momentum-strategy] %s: failed to get price: %v", c.Ticker, err)
+			continue
+		}
+
+		quantity := s.deps.Params.Quantity
+		if quantity.IsZero() {
+			quantity = defaultQuantity
+		}
+		if err := s.deps.ExecuteOrder(ctx, c.Ticker, "BUY", int(quantity.IntPart()), price); err != nil {
+			log.Printf("[momentum-strategy] %s: order execution failed: %v", c.Ticker, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	Register("dca2", func() Strategy { return &dca2Strategy{} })
+}
+
+// defaultMaxOrderCount is how many laddered entries dca2 plans when an
+// instance doesn't set MaxOrderCount.
+const defaultMaxOrderCount = 5
+
+// dca2Rung is one planned laddered entry: BUY Quantity of the instance's
+// symbol once the market price drops to or below Price.
+type dca2Rung struct {
+	Price    decimal.Decimal `bson:"price"`
+	Quantity decimal.Decimal `bson:"quantity"`
+	Filled   bool            `bson:"filled"`
+}
+
+// dca2State is everything dca2Strategy persists via Deps.SaveState so a
+// restart can recover an open ladder (RecoverWhenStart).
+type dca2State struct {
+	Rungs          []dca2Rung      `bson:"rungs"`
+	AverageCost    decimal.Decimal `bson:"average_cost"`
+	AccumulatedQty decimal.Decimal `bson:"accumulated_qty"`
+	CoolDownUntil  time.Time       `bson:"cool_down_until"`
+}
+
+// hasOpenLadder reports whether a ladder has been planned and not yet fully
+// exited (take-profit not yet hit).
+func (s dca2State) hasOpenLadder() bool {
+	return len(s.Rungs) > 0
+}
+
+// dca2Strategy is a DCA2-style ("double" dollar-cost-averaging) scaled-entry
+// strategy, modeled on bbgo's dca2: it plans MaxOrderCount laddered BUY
+// orders below the current price, spaced by PriceDeviation and sized out of
+// a fixed QuoteInvestment budget, then places a single take-profit SELL once
+// filled, sized at the ladder's average cost plus TakeProfitRatio. A loss on
+// exit beyond CircuitBreakLossThreshold pauses re-entry for CoolDownInterval.
+//
+// Unlike momentum, which only ever places market orders at the live price,
+// dca2 simulates resting limit orders: each Run tick checks the live price
+// against each planned rung and "fills" any rung the price has reached.
+type dca2Strategy struct {
+	BaseStrategy
+	deps  Deps
+	state dca2State
+}
+
+func (s *dca2Strategy) ID() string { return "dca2" }
+
+func (s *dca2Strategy) Init(ctx context.Context, deps Deps) error {
+	if deps.Params.Symbol == "" {
+		return fmt.Errorf("dca2 strategy: symbol is required")
+	}
+	if deps.Params.QuoteInvestment.IsZero() {
+		return fmt.Errorf("dca2 strategy: quote_investment is required")
+	}
+	if deps.Params.PriceDeviation.IsZero() {
+		return fmt.Errorf("dca2 strategy: price_deviation is required")
+	}
+	if deps.Params.TakeProfitRatio.IsZero() {
+		return fmt.Errorf("dca2 strategy: take_profit_ratio is required")
+	}
+	s.deps = deps
+
+	if deps.Params.RecoverWhenStart && deps.LoadState != nil {
+		ok, err := deps.LoadState(ctx, &s.state)
+		if err != nil {
+			return fmt.Errorf("dca2 strategy: failed to recover state: %w", err)
+		}
+		if ok {
+			log.Printf("[dca2-strategy] %s: recovered ladder with %d rung(s), average cost %s",
+				deps.Params.Symbol, len(s.state.Rungs), s.state.AverageCost.String())
+		}
+	}
+
+	if s.deps.OnReady != nil {
+		s.deps.OnReady()
+	}
+	return nil
+}
+
+func (s *dca2Strategy) Run(ctx context.Context) error {
+	symbol := s.deps.Params.Symbol
+
+	if !s.state.CoolDownUntil.IsZero() && time.Now().Before(s.state.CoolDownUntil) {
+		return nil
+	}
+
+	price, err := s.deps.GetPrice(symbol)
+	if err != nil {
+		err = fmt.Errorf("dca2 strategy: failed to get price: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+
+	if !s.state.hasOpenLadder() {
+		s.state = dca2State{Rungs: s.planLadder(price)}
+	}
+
+	if err := s.fillDueRungs(ctx, symbol, price); err != nil {
+		return err
+	}
+
+	if s.state.AccumulatedQty.IsPositive() {
+		takeProfitPrice := s.state.AverageCost.Mul(decimal.NewFromInt(1).Add(s.deps.Params.TakeProfitRatio))
+		if price.GreaterThanOrEqual(takeProfitPrice) {
+			return s.closeLadder(ctx, symbol, price)
+		}
+	}
+
+	return s.persist(ctx)
+}
+
+// planLadder computes this instance's MaxOrderCount rungs at prices
+// price*(1-deviation)^i for i=0..N-1, then allocates QuoteInvestment across
+// them weighted by depth (deeper, cheaper rungs get a bigger share, per the
+// "scaled entry" in the strategy's name), dropping and redistributing any
+// rung whose allocation would fall under MinNotional.
+func (s *dca2Strategy) planLadder(price decimal.Decimal) []dca2Rung {
+	n := s.deps.Params.MaxOrderCount
+	if n <= 0 {
+		n = defaultMaxOrderCount
+	}
+	deviation := decimal.NewFromInt(1).Sub(s.deps.Params.PriceDeviation)
+
+	prices := make([]decimal.Decimal, n)
+	weights := make([]decimal.Decimal, n)
+	level := decimal.NewFromInt(1)
+	for i := 0; i < n; i++ {
+		prices[i] = price.Mul(level)
+		weights[i] = decimal.NewFromInt(int64(i + 1))
+		level = level.Mul(deviation)
+	}
+
+	active := make([]bool, n)
+	for i := range active {
+		active[i] = true
+	}
+
+	for {
+		totalWeight := decimal.Zero
+		for i, on := range active {
+			if on {
+				totalWeight = totalWeight.Add(weights[i])
+			}
+		}
+		if totalWeight.IsZero() {
+			break
+		}
+
+		removedAny := false
+		for i, on := range active {
+			if !on {
+				continue
+			}
+			notional := s.deps.Params.QuoteInvestment.Mul(weights[i]).Div(totalWeight)
+			if s.deps.Params.MinNotional.IsPositive() && notional.LessThan(s.deps.Params.MinNotional) {
+				active[i] = false
+				removedAny = true
+			}
+		}
+		if !removedAny {
+			break
+		}
+	}
+
+	totalWeight := decimal.Zero
+	for i, on := range active {
+		if on {
+			totalWeight = totalWeight.Add(weights[i])
+		}
+	}
+
+	var rungs []dca2Rung
+	if totalWeight.IsZero() {
+		return rungs
+	}
+	for i, on := range active {
+		if !on {
+			continue
+		}
+		notional := s.deps.Params.QuoteInvestment.Mul(weights[i]).Div(totalWeight)
+		rungs = append(rungs, dca2Rung{
+			Price:    prices[i],
+			Quantity: notional.Div(prices[i]),
+		})
+	}
+	return rungs
+}
+
+// fillDueRungs executes every unfilled rung the market price has reached
+// (rung.Price >= price, since rungs sit below the ladder's anchor) and rolls
+// it into the running average cost.
+func (s *dca2Strategy) fillDueRungs(ctx context.Context, symbol string, price decimal.Decimal) error {
+	for i := range s.state.Rungs {
+		rung := &s.state.Rungs[i]
+		if rung.Filled || price.GreaterThan(rung.Price) {
+			continue
+		}
+
+		if err := s.deps.ExecuteFractionalOrder(ctx, symbol, "BUY", rung.Quantity, rung.Price); err != nil {
+			log.Printf("[dca2-strategy] %s: rung fill failed: %v", symbol, err)
+			continue
+		}
+
+		filledNotional := s.state.AverageCost.Mul(s.state.AccumulatedQty)
+		s.state.AccumulatedQty = s.state.AccumulatedQty.Add(rung.Quantity)
+		s.state.AverageCost = filledNotional.Add(rung.Price.Mul(rung.Quantity)).Div(s.state.AccumulatedQty)
+		rung.Filled = true
+	}
+	return nil
+}
+
+// closeLadder sells the full accumulated position at price (the ladder's
+// take-profit target was reached), then resets state for the next ladder,
+// entering a cool-down if the exit lost more than CircuitBreakLossThreshold.
+func (s *dca2Strategy) closeLadder(ctx context.Context, symbol string, price decimal.Decimal) error {
+	qty := s.state.AccumulatedQty
+	cost := s.state.AverageCost
+
+	if err := s.deps.ExecuteFractionalOrder(ctx, symbol, "SELL", qty, price); err != nil {
+		log.Printf("[dca2-strategy] %s: take-profit exit failed: %v", symbol, err)
+		return s.persist(ctx)
+	}
+
+	pnlRatio := price.Sub(cost).Div(cost)
+	next := dca2State{}
+	if s.deps.Params.CircuitBreakLossThreshold.IsPositive() && pnlRatio.Neg().GreaterThanOrEqual(s.deps.Params.CircuitBreakLossThreshold) {
+		next.CoolDownUntil = time.Now().Add(s.deps.Params.CoolDownInterval)
+		log.Printf("[dca2-strategy] %s: exit lost %s, cooling down until %s", symbol, pnlRatio.String(), next.CoolDownUntil.Format(time.RFC3339))
+	}
+	s.state = next
+
+	return s.persist(ctx)
+}
+
+// persist saves the current ladder state so a restart can recover it, a
+// no-op if Deps.SaveState wasn't wired up (Mongo not configured).
+func (s *dca2Strategy) persist(ctx context.Context) error {
+	if s.deps.SaveState == nil {
+		return nil
+	}
+	if err := s.deps.SaveState(ctx, &s.state); err != nil {
+		log.Printf("[dca2-strategy] %s: failed to persist state: %v", s.deps.Params.Symbol, err)
+	}
+	return nil
+}
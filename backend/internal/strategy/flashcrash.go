@@ -0,0 +1,114 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	Register("flashcrash", func() Strategy { return &flashCrashStrategy{} })
+}
+
+// flashCrashRung is one resting deep BUY the grid has placed below the
+// anchor price.
+type flashCrashRung struct {
+	OrderID string
+	Price   decimal.Decimal
+}
+
+// flashCrashStrategy is a "catch the falling knife" market maker: it places
+// GridNum deep BUY orders of BaseQuantity (Quantity) each, spaced
+// Percentage (PriceDeviation) apart below the current price, so a sudden
+// liquidation cascade gets filled cheap on the way down. Every Run tick it
+// checks whether the price has drifted far enough from the grid's anchor
+// that the grid needs recentering (having moved past the first rung), and
+// if so cancels the stale grid and re-places it around the new price.
+type flashCrashStrategy struct {
+	BaseStrategy
+	deps   Deps
+	anchor decimal.Decimal
+	rungs  []flashCrashRung
+}
+
+func (s *flashCrashStrategy) ID() string { return "flashcrash" }
+
+func (s *flashCrashStrategy) Init(ctx context.Context, deps Deps) error {
+	if deps.Params.Symbol == "" {
+		return fmt.Errorf("flashcrash strategy: symbol is required")
+	}
+	if deps.Params.GridNum <= 0 {
+		return fmt.Errorf("flashcrash strategy: grid_num is required")
+	}
+	if deps.Params.PriceDeviation.IsZero() {
+		return fmt.Errorf("flashcrash strategy: price_deviation is required")
+	}
+	if deps.Params.Quantity.IsZero() {
+		return fmt.Errorf("flashcrash strategy: quantity is required")
+	}
+	s.deps = deps
+
+	if s.deps.OnReady != nil {
+		s.deps.OnReady()
+	}
+	return nil
+}
+
+func (s *flashCrashStrategy) Run(ctx context.Context) error {
+	symbol := s.deps.Params.Symbol
+
+	price, err := s.deps.GetPrice(symbol)
+	if err != nil {
+		err = fmt.Errorf("flashcrash strategy: failed to get price: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+
+	if len(s.rungs) > 0 && !s.needsRecenter(price) {
+		return nil
+	}
+
+	for _, rung := range s.rungs {
+		if err := s.deps.CancelOrder(ctx, rung.OrderID); err != nil {
+			log.Printf("[flashcrash-strategy] %s: failed to cancel stale rung %s: %v", symbol, rung.OrderID, err)
+		}
+	}
+
+	s.anchor = price
+	s.rungs = s.placeGrid(ctx, symbol, price)
+	return nil
+}
+
+// needsRecenter reports whether price has drifted from the grid's anchor by
+// at least one rung's worth of PriceDeviation, in either direction, meaning
+// the ladder no longer sits the right distance below the live price and
+// should be canceled and re-placed around it.
+func (s *flashCrashStrategy) needsRecenter(price decimal.Decimal) bool {
+	drift := price.Sub(s.anchor).Div(s.anchor).Abs()
+	return drift.GreaterThanOrEqual(s.deps.Params.PriceDeviation)
+}
+
+// placeGrid places GridNum BUY rungs at price*(1-PriceDeviation)^i for
+// i=1..GridNum, each sized Quantity.
+func (s *flashCrashStrategy) placeGrid(ctx context.Context, symbol string, price decimal.Decimal) []flashCrashRung {
+	deviation := decimal.NewFromInt(1).Sub(s.deps.Params.PriceDeviation)
+
+	rungs := make([]flashCrashRung, 0, s.deps.Params.GridNum)
+	level := deviation
+	for i := 0; i < s.deps.Params.GridNum; i++ {
+		rungPrice := price.Mul(level)
+		level = level.Mul(deviation)
+
+		orderID, err := s.deps.PlaceMakerOrder(ctx, symbol, "BUY", s.deps.Params.Quantity, rungPrice)
+		if err != nil {
+			log.Printf("[flashcrash-strategy] %s: failed to place rung at %s: %v", symbol, rungPrice.String(), err)
+			continue
+		}
+		rungs = append(rungs, flashCrashRung{OrderID: orderID, Price: rungPrice})
+	}
+	return rungs
+}
@@ -0,0 +1,49 @@
+package strategy
+
+import (
+	"sort"
+	"sync"
+)
+
+// Factory builds a fresh, uninitialized Strategy. Called once per
+// InstanceConfig entry, since each instance (e.g. momentum on BTCUSDT vs.
+// momentum on ETHUSDT) needs its own state.
+type Factory func() Strategy
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Factory)
+)
+
+// Register adds a strategy factory under id. Built-in strategies register
+// themselves from an init() in the file that defines them.
+func Register(id string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[id] = factory
+}
+
+// New builds a fresh instance of the strategy registered under id. ok is
+// false if nothing is registered under that id.
+func New(id string) (s Strategy, ok bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	factory, ok := registry[id]
+	if !ok {
+		return nil, false
+	}
+	return factory(), true
+}
+
+// IDs returns every registered strategy id, sorted for stable output.
+func IDs() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	ids := make([]string, 0, len(registry))
+	for id := range registry {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,185 @@
+// Package strategy implements the automated-trading strategy plugin system:
+// a pluggable registry of Strategy implementations, each instantiated from a
+// YAML-configured InstanceConfig and driven by orchestration code (today,
+// the asynq worker's TypeAutomatedTrading task). It deliberately has no
+// dependency on internal/services — services.TradingService depends on this
+// package, not the other way around — so Deps is built from plain funcs and
+// third-party types instead of concrete service structs.
+package strategy
+
+import (
+	"context"
+	"time"
+
+	"scope-backend/internal/feed"
+
+	"github.com/shopspring/decimal"
+)
+
+// ScreenerCandidate is the subset of a services.ScreenerResult a strategy
+// needs to evaluate a candidate symbol.
+type ScreenerCandidate struct {
+	Ticker string
+	Change string // formatted like "3.25%", as stored by the screener ingestion job
+}
+
+// InstanceConfig is one configured instance of a registered strategy: which
+// strategy ID to run, bound to a single symbol with that symbol's
+// parameters. One registered ID can back many instances, e.g. "momentum"
+// running separately for BTCUSDT and ETHUSDT with different quantities.
+// Every strategy shares this one flat struct and reads only the fields it
+// needs, rather than each strategy defining its own config type.
+type InstanceConfig struct {
+	ID             string
+	Symbol         string
+	Quantity       decimal.Decimal
+	Interval       time.Duration
+	PriceDeviation decimal.Decimal
+
+	// The following fields configure the "dca2" strategy (see dca2.go).
+	QuoteInvestment           decimal.Decimal
+	MaxOrderCount             int
+	TakeProfitRatio           decimal.Decimal
+	MinNotional               decimal.Decimal
+	CoolDownInterval          time.Duration
+	CircuitBreakLossThreshold decimal.Decimal
+	RecoverWhenStart          bool
+
+	// The following fields configure the "xfunding" strategy (see xfunding.go).
+	// QuoteInvestment above caps the total hedge size.
+	IncrementalQuoteQuantity decimal.Decimal
+	Leverage                 decimal.Decimal
+	ShortFundingRateHigh     decimal.Decimal
+	ShortFundingRateLow      decimal.Decimal
+
+	// The following fields configure the "bollinger_pp" strategy (see
+	// bollinger.go). Quantity above sizes both quotes.
+	Window       int
+	BandWidth    decimal.Decimal
+	NeutralWidth decimal.Decimal
+	Spread       decimal.Decimal
+
+	// The following field configures the "flashcrash" strategy (see
+	// flashcrash.go), alongside Quantity (each grid rung's BUY size) and
+	// PriceDeviation (the percentage drop between rungs).
+	GridNum int
+}
+
+// Leg identifies one side of a cross-venue hedge (see the "xfunding"
+// strategy), tagging the TradeOrder records each side produces so PnL can be
+// reconstructed per leg.
+type Leg string
+
+const (
+	LegSpot    Leg = "spot"
+	LegFutures Leg = "futures"
+)
+
+// Deps are the capabilities and lifecycle hooks a strategy's Init receives.
+// They're plain funcs rather than concrete *services types so this package
+// stays a leaf that services imports, not the reverse.
+type Deps struct {
+	// ExecuteOrder places a market order; backed by (*services.TradingService).ExecuteOrder.
+	ExecuteOrder func(ctx context.Context, symbol, side string, quantity int, price decimal.Decimal) error
+	// ExecuteFractionalOrder is ExecuteOrder for strategies that size
+	// positions in fractional quantities; backed by
+	// (*services.TradingService).ExecuteFractionalOrder.
+	ExecuteFractionalOrder func(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) error
+	// GetPrice returns the live price for symbol; backed by (*services.MarketService).GetPrice.
+	GetPrice func(symbol string) (decimal.Decimal, error)
+	// ScreenerCandidates returns up to limit candidates tagged screenerStrategy
+	// (e.g. "momentum"); backed by (*services.ScreenerService).GetScreenerResults.
+	ScreenerCandidates func(ctx context.Context, screenerStrategy string, limit int64) ([]ScreenerCandidate, error)
+
+	// SaveState persists state (a pointer to a struct, bson-tagged like any
+	// other persisted document) so a restart can recover it via LoadState;
+	// backed by (*services.StrategyStateStore).Save, scoped to this instance.
+	// It is a no-op if Mongo isn't configured.
+	SaveState func(ctx context.Context, state any) error
+	// LoadState decodes this instance's last-persisted state into out, which
+	// must be a pointer of the same type previously passed to SaveState. ok
+	// is false if nothing has been persisted yet (or Mongo isn't configured).
+	LoadState func(ctx context.Context, out any) (ok bool, err error)
+
+	// GetFundingRate returns symbol's current perpetual-futures funding rate
+	// (e.g. 0.0005 = 0.05%); backed by (*services.FundingRateService).GetFundingRate.
+	GetFundingRate func(symbol string) (decimal.Decimal, error)
+	// OpenFuturesPosition opens (or adds to) a leveraged futures position,
+	// recording a TradeOrder tagged LegFutures; backed by
+	// (*services.TradingService).OpenFuturesPosition.
+	OpenFuturesPosition func(ctx context.Context, symbol, side string, quantity, price, leverage decimal.Decimal) error
+	// CloseFuturesPosition closes (or reduces) a futures position, recording
+	// a TradeOrder tagged LegFutures; backed by
+	// (*services.TradingService).CloseFuturesPosition.
+	CloseFuturesPosition func(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) error
+	// TransferCollateral moves amount of symbol's quote currency between
+	// venue wallets (e.g. funding a futures short's margin from the spot
+	// wallet); backed by (*services.TradingService).TransferCollateral.
+	TransferCollateral func(ctx context.Context, symbol string, from, to Leg, amount decimal.Decimal) error
+
+	// GetSMA and GetStdDev compute rolling indicators over the last window
+	// candles at this instance's Interval timeframe; backed by
+	// (*services.IndicatorService).SMA/StdDev.
+	GetSMA    func(symbol string, window int) (decimal.Decimal, error)
+	GetStdDev func(symbol string, window int) (decimal.Decimal, error)
+	// GetBestBidAsk returns the top-of-book bid/ask prices for symbol, nil
+	// if that side of the book is currently empty; backed by
+	// (*services.MarketService).GetBestBidAsk.
+	GetBestBidAsk func(symbol string) (bid, ask *decimal.Decimal, err error)
+	// PlaceMakerOrder quotes a resting limit order for this strategy
+	// instance and tracks it so a later CancelAllMakerOrders call can find
+	// it; backed by (*services.TradingService).PlaceMakerOrder, scoped to
+	// this instance.
+	PlaceMakerOrder func(ctx context.Context, symbol, side string, quantity, price decimal.Decimal) (orderID string, err error)
+	// CancelAllMakerOrders cancels every order this instance currently has
+	// resting (e.g. before repricing on a new kline), returning their IDs;
+	// backed by (*services.TradingService).CancelAllMakerOrders, scoped to
+	// this instance.
+	CancelAllMakerOrders func(ctx context.Context) ([]string, error)
+	// CancelOrder cancels a single order this instance has resting by ID
+	// (e.g. to drop just the stale rungs of a grid instead of the whole
+	// grid); backed by (*services.TradingService).CancelOrder, scoped to
+	// this instance.
+	CancelOrder func(ctx context.Context, orderID string) error
+
+	// Params is this instance's configuration, as loaded from config.yaml's
+	// strategies list.
+	Params InstanceConfig
+
+	// OnReady is invoked once Init has bound the strategy to live data and
+	// it's safe to start scheduling Run, so orchestration code can delay
+	// enqueuing ticks until then.
+	OnReady func()
+	// OnClosed is invoked by strategies that run their own internal loop
+	// (unlike the tick-per-Run built-ins) when that loop exits, so
+	// orchestration code can stop scheduling further ticks. Tick-per-Run
+	// strategies never call it.
+	OnClosed func()
+	// OnError is invoked whenever a strategy encounters an error it can
+	// continue past (Run still returns the error too, for logging/retry).
+	OnError func(error)
+}
+
+// Strategy is one automated-trading algorithm, instantiated per (strategy
+// ID, symbol) pair from an InstanceConfig and driven by orchestration code.
+type Strategy interface {
+	// ID identifies this strategy in the registry and in config.yaml's
+	// strategies list.
+	ID() string
+	// Init binds the strategy to deps before the first Run.
+	Init(ctx context.Context, deps Deps) error
+	// Run executes one tick of the strategy.
+	Run(ctx context.Context) error
+	// OnKline and OnTick are live market-data hooks, for strategies that
+	// react to feed updates instead of (or in addition to) polling via Run.
+	OnKline(kline feed.Kline)
+	OnTick(trade feed.Trade)
+}
+
+// BaseStrategy provides no-op OnKline/OnTick implementations for strategies
+// that only act on a schedule via Run, so they don't need to implement
+// hooks they don't use.
+type BaseStrategy struct{}
+
+func (BaseStrategy) OnKline(feed.Kline) {}
+func (BaseStrategy) OnTick(feed.Trade)  {}
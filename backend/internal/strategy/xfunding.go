@@ -0,0 +1,190 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	Register("xfunding", func() Strategy { return &xFundingStrategy{} })
+}
+
+// xFundingState is everything xFundingStrategy persists via Deps.SaveState
+// so a restart can recover an open hedge without re-entering from scratch.
+type xFundingState struct {
+	InvestedQuote decimal.Decimal `bson:"invested_quote"`
+	SpotQty       decimal.Decimal `bson:"spot_qty"`
+	FuturesQty    decimal.Decimal `bson:"futures_qty"`
+}
+
+// hasOpenHedge reports whether a spot/futures hedge is currently held.
+func (s xFundingState) hasOpenHedge() bool {
+	return s.InvestedQuote.IsPositive()
+}
+
+// xFundingStrategy is a cross-exchange funding-rate arbitrage strategy: it
+// hedges a spot long against an equal-notional perpetual-futures short to
+// harvest funding payments, entering in IncrementalQuoteQuantity chunks (up
+// to QuoteInvestment) while the funding rate stays at or above
+// ShortFundingRateHigh, and fully unwinding both legs once it drops to or
+// below ShortFundingRateLow. Unlike dca2, which only ever holds one side of
+// a trade, both legs are opened and closed together so the position stays
+// market-neutral throughout.
+type xFundingStrategy struct {
+	BaseStrategy
+	deps  Deps
+	state xFundingState
+}
+
+func (s *xFundingStrategy) ID() string { return "xfunding" }
+
+func (s *xFundingStrategy) Init(ctx context.Context, deps Deps) error {
+	if deps.Params.Symbol == "" {
+		return fmt.Errorf("xfunding strategy: symbol is required")
+	}
+	if deps.Params.QuoteInvestment.IsZero() {
+		return fmt.Errorf("xfunding strategy: quote_investment is required")
+	}
+	if deps.Params.IncrementalQuoteQuantity.IsZero() {
+		return fmt.Errorf("xfunding strategy: incremental_quote_quantity is required")
+	}
+	if deps.Params.Leverage.IsZero() {
+		return fmt.Errorf("xfunding strategy: leverage is required")
+	}
+	if deps.GetFundingRate == nil || deps.OpenFuturesPosition == nil || deps.CloseFuturesPosition == nil || deps.TransferCollateral == nil {
+		return fmt.Errorf("xfunding strategy: futures venue is not configured")
+	}
+	s.deps = deps
+
+	if deps.LoadState != nil {
+		ok, err := deps.LoadState(ctx, &s.state)
+		if err != nil {
+			return fmt.Errorf("xfunding strategy: failed to recover state: %w", err)
+		}
+		if ok {
+			log.Printf("[xfunding-strategy] %s: recovered hedge, invested %s, spot qty %s, futures qty %s",
+				deps.Params.Symbol, s.state.InvestedQuote.String(), s.state.SpotQty.String(), s.state.FuturesQty.String())
+		}
+	}
+
+	if s.deps.OnReady != nil {
+		s.deps.OnReady()
+	}
+	return nil
+}
+
+func (s *xFundingStrategy) Run(ctx context.Context) error {
+	symbol := s.deps.Params.Symbol
+
+	rate, err := s.deps.GetFundingRate(symbol)
+	if err != nil {
+		err = fmt.Errorf("xfunding strategy: failed to get funding rate: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+
+	switch {
+	case rate.GreaterThanOrEqual(s.deps.Params.ShortFundingRateHigh) && s.state.InvestedQuote.LessThan(s.deps.Params.QuoteInvestment):
+		return s.enter(ctx, symbol)
+	case s.state.hasOpenHedge() && rate.LessThanOrEqual(s.deps.Params.ShortFundingRateLow):
+		return s.unwind(ctx, symbol)
+	}
+
+	return nil
+}
+
+// enter buys one IncrementalQuoteQuantity chunk of spot (clamped to what's
+// left of QuoteInvestment) and opens an equal-notional futures short at
+// Leverage, funding the short's margin by transferring that chunk's
+// collateral from the spot venue to the futures venue.
+func (s *xFundingStrategy) enter(ctx context.Context, symbol string) error {
+	chunk := decimal.Min(s.deps.Params.IncrementalQuoteQuantity, s.deps.Params.QuoteInvestment.Sub(s.state.InvestedQuote))
+	if !chunk.IsPositive() {
+		return nil
+	}
+
+	price, err := s.deps.GetPrice(symbol)
+	if err != nil {
+		err = fmt.Errorf("xfunding strategy: failed to get price: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+	qty := chunk.Div(price)
+
+	if err := s.deps.ExecuteFractionalOrder(ctx, symbol, "BUY", qty, price); err != nil {
+		log.Printf("[xfunding-strategy] %s: spot entry failed: %v", symbol, err)
+		return nil
+	}
+
+	// The spot fill is real and irreversible from here, so record it against
+	// InvestedQuote/SpotQty immediately: if the futures leg below fails, state
+	// must reflect the unhedged spot position rather than pretend the chunk
+	// never happened, or the next tick would buy another chunk on top of it.
+	s.state.InvestedQuote = s.state.InvestedQuote.Add(chunk)
+	s.state.SpotQty = s.state.SpotQty.Add(qty)
+
+	if err := s.deps.OpenFuturesPosition(ctx, symbol, "SELL", qty, price, s.deps.Params.Leverage); err != nil {
+		log.Printf("[xfunding-strategy] %s: futures entry failed: %v", symbol, err)
+		return s.persist(ctx)
+	}
+	if err := s.deps.TransferCollateral(ctx, symbol, LegSpot, LegFutures, chunk); err != nil {
+		log.Printf("[xfunding-strategy] %s: collateral transfer failed: %v", symbol, err)
+	}
+
+	s.state.FuturesQty = s.state.FuturesQty.Add(qty)
+	return s.persist(ctx)
+}
+
+// unwind closes both legs in full and returns the futures venue's remaining
+// collateral to the spot venue.
+func (s *xFundingStrategy) unwind(ctx context.Context, symbol string) error {
+	price, err := s.deps.GetPrice(symbol)
+	if err != nil {
+		err = fmt.Errorf("xfunding strategy: failed to get price: %w", err)
+		if s.deps.OnError != nil {
+			s.deps.OnError(err)
+		}
+		return err
+	}
+
+	if err := s.deps.ExecuteFractionalOrder(ctx, symbol, "SELL", s.state.SpotQty, price); err != nil {
+		log.Printf("[xfunding-strategy] %s: spot unwind failed: %v", symbol, err)
+		return s.persist(ctx)
+	}
+
+	// The spot leg is already closed at this point, so zero it out before
+	// attempting the futures leg: if that fails below, state must not claim
+	// SpotQty is still open, or the next tick's unwind would try to sell a
+	// spot position that no longer exists.
+	s.state.SpotQty = decimal.Zero
+
+	if err := s.deps.CloseFuturesPosition(ctx, symbol, "BUY", s.state.FuturesQty, price); err != nil {
+		log.Printf("[xfunding-strategy] %s: futures unwind failed: %v", symbol, err)
+		return s.persist(ctx)
+	}
+	if err := s.deps.TransferCollateral(ctx, symbol, LegFutures, LegSpot, s.state.InvestedQuote); err != nil {
+		log.Printf("[xfunding-strategy] %s: collateral transfer failed: %v", symbol, err)
+	}
+
+	s.state = xFundingState{}
+	return s.persist(ctx)
+}
+
+// persist saves the current hedge state so a restart can recover it, a
+// no-op if Deps.SaveState wasn't wired up (Mongo not configured).
+func (s *xFundingStrategy) persist(ctx context.Context) error {
+	if s.deps.SaveState == nil {
+		return nil
+	}
+	if err := s.deps.SaveState(ctx, &s.state); err != nil {
+		log.Printf("[xfunding-strategy] %s: failed to persist state: %v", s.deps.Params.Symbol, err)
+	}
+	return nil
+}
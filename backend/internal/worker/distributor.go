@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 
+	"scope-backend/internal/services"
+
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
 )
@@ -15,6 +17,7 @@ const (
 	TypePortfolioUpdate  = "portfolio:update"
 	TypeDataIngestion    = "data:ingest"
 	TypeAutomatedTrading = "trading:automated"
+	TypeGapStrategy      = "strategy:gap"
 )
 
 // Task Payloads
@@ -28,11 +31,18 @@ type PortfolioUpdatePayload struct {
 	PortfolioID string
 }
 
+// GapStrategyPayload carries the config for one tick of the cross-exchange
+// gap market-making strategy (see services.GapStrategy).
+type GapStrategyPayload struct {
+	Config services.GapStrategyConfig
+}
+
 // TaskDistributorInterface interface
 type TaskDistributorInterface interface {
 	DistributeTaskSendEmail(payload *EmailDeliveryPayload, opts ...asynq.Option) error
 	DistributeTaskPortfolioUpdate(payload *PortfolioUpdatePayload, opts ...asynq.Option) error
 	DistributeTaskAutomatedTrading(opts ...asynq.Option) error
+	DistributeTaskGapStrategy(payload *GapStrategyPayload, opts ...asynq.Option) error
 }
 
 type TaskDistributor struct {
@@ -83,3 +93,17 @@ func (distributor *TaskDistributor) DistributeTaskAutomatedTrading(opts ...asynq
 	log.Printf("Enqueued automated trading task: id=%s queue=%s", info.ID, info.Queue)
 	return nil
 }
+
+func (distributor *TaskDistributor) DistributeTaskGapStrategy(payload *GapStrategyPayload, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+	task := asynq.NewTask(TypeGapStrategy, jsonPayload, opts...)
+	info, err := distributor.client.Enqueue(task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	log.Printf("Enqueued gap strategy task: id=%s queue=%s symbol=%s", info.ID, info.Queue, payload.Config.Symbol)
+	return nil
+}
@@ -5,14 +5,47 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sync"
+
+	"scope-backend/internal/feed"
+	"scope-backend/internal/services"
 
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
 )
 
-func StartWorkerServer(rdb *redis.Client) {
+// TaskProcessor runs the asynq worker server. Unlike the stateless
+// TypeEmailDelivery/TypePortfolioUpdate handlers, TypeAutomatedTrading and
+// TypeGapStrategy need access to live services, so they're registered as
+// methods on TaskProcessor instead of package-level functions.
+type TaskProcessor struct {
+	redisClient    *redis.Client
+	tradingService *services.TradingService
+	sourceFeed     feed.MarketFeed
+	feeBudget      *services.FeeBudget // nil disables the daily budget short-circuit
+
+	mu            sync.Mutex
+	gapStrategies map[string]*services.GapStrategy // by symbol, built lazily on first tick
+}
+
+// NewTaskProcessor builds a TaskProcessor. sourceFeed may be nil if no live
+// market feed is configured; TypeGapStrategy tasks will then fail fast
+// rather than silently quoting against stale data. feeBudget may be nil,
+// which disables the TypeAutomatedTrading budget short-circuit.
+func NewTaskProcessor(rdb *redis.Client, tradingService *services.TradingService, sourceFeed feed.MarketFeed, feeBudget *services.FeeBudget) *TaskProcessor {
+	return &TaskProcessor{
+		redisClient:    rdb,
+		tradingService: tradingService,
+		sourceFeed:     sourceFeed,
+		feeBudget:      feeBudget,
+		gapStrategies:  make(map[string]*services.GapStrategy),
+	}
+}
+
+// Start runs the asynq worker server until it errors, blocking the caller.
+func (p *TaskProcessor) Start() error {
 	srv := asynq.NewServer(
-		asynq.RedisClientOpt{Addr: rdb.Options().Addr},
+		asynq.RedisClientOpt{Addr: p.redisClient.Options().Addr},
 		asynq.Config{
 			Concurrency: 10,
 			Queues: map[string]int{
@@ -26,10 +59,10 @@ func StartWorkerServer(rdb *redis.Client) {
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(TypeEmailDelivery, HandleEmailDeliveryTask)
 	mux.HandleFunc(TypePortfolioUpdate, HandlePortfolioUpdateTask)
+	mux.HandleFunc(TypeAutomatedTrading, p.HandleAutomatedTradingTask)
+	mux.HandleFunc(TypeGapStrategy, p.HandleGapStrategyTask)
 
-	if err := srv.Run(mux); err != nil {
-		log.Fatalf("could not run server: %v", err)
-	}
+	return srv.Run(mux)
 }
 
 func HandleEmailDeliveryTask(ctx context.Context, task *asynq.Task) error {
@@ -51,3 +84,51 @@ func HandlePortfolioUpdateTask(ctx context.Context, task *asynq.Task) error {
 	// Portfolio update logic...
 	return nil
 }
+
+func (p *TaskProcessor) HandleAutomatedTradingTask(ctx context.Context, task *asynq.Task) error {
+	if p.feeBudget != nil {
+		exhausted, err := p.feeBudget.IsExhausted(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check fee budget: %w", err)
+		}
+		if exhausted {
+			log.Println("Skipping automated trading tick: daily fee/volume budget exhausted")
+			return nil
+		}
+	}
+	return p.tradingService.RunAutomatedStrategy(ctx)
+}
+
+func (p *TaskProcessor) HandleGapStrategyTask(ctx context.Context, task *asynq.Task) error {
+	var payload GapStrategyPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	strategy, err := p.gapStrategy(payload.Config)
+	if err != nil {
+		return fmt.Errorf("failed to build gap strategy: %v: %w", err, asynq.SkipRetry)
+	}
+	return strategy.Run(ctx)
+}
+
+// gapStrategy returns the cached GapStrategy for cfg.Symbol, building (and
+// subscribing it to the source feed) on first use.
+func (p *TaskProcessor) gapStrategy(cfg services.GapStrategyConfig) (*services.GapStrategy, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if existing, ok := p.gapStrategies[cfg.Symbol]; ok {
+		return existing, nil
+	}
+	if p.sourceFeed == nil {
+		return nil, fmt.Errorf("no market feed configured")
+	}
+
+	strategy, err := services.NewGapStrategy(cfg, p.sourceFeed, p.tradingService, p.redisClient)
+	if err != nil {
+		return nil, err
+	}
+	p.gapStrategies[cfg.Symbol] = strategy
+	return strategy, nil
+}